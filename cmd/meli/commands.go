@@ -0,0 +1,359 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+)
+
+var errUsage = errors.New("wrong arguments, run meli -h for help")
+
+func newFlagSet(c *cli, name string) *flag.FlagSet {
+	flags := flag.NewFlagSet("meli "+name, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	return flags
+}
+
+func loginCommand(c *cli, args []string) error {
+
+	p := c.config.profile(c.profileName)
+
+	flags := newFlagSet(c, "login")
+	flags.Int64Var(&p.ClientID, "client-id", p.ClientID, "application id")
+	flags.StringVar(&p.Secret, "secret", p.Secret, "application secret")
+	flags.StringVar(&p.CallBackURL, "callback", p.CallBackURL, "local callback URL registered for the application")
+	flags.StringVar(&p.SiteID, "site", p.SiteID, "site the user logs in to, i.e. MLA")
+	timeout := flags.Duration("timeout", 5*time.Minute, "time to wait for the login")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if p.ClientID == 0 || p.Secret == "" {
+		return errors.New("-client-id and -secret are required the first time")
+	}
+
+	auth, err := login(p, *timeout, c.printf)
+	if err != nil {
+		return err
+	}
+
+	p.Authorization = auth
+
+	if err := c.config.save(); err != nil {
+		return err
+	}
+
+	c.printf("Logged in as user %d within profile %q\n", auth.UserID, c.profileName)
+	return nil
+}
+
+func tokenCommand(c *cli, args []string) error {
+
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	return c.withClient(func(client *sdk.Client) error {
+
+		switch args[0] {
+		case "show":
+		case "refresh":
+			if err := client.RefreshAuthorization(); err != nil {
+				return err
+			}
+		default:
+			return errUsage
+		}
+
+		auth := client.Authorization()
+
+		return printJSON(c.stdout, struct {
+			sdk.Authorization
+			ExpiresAt time.Time `json:"expires_at"`
+		}{auth, time.Unix(auth.ReceivedAt+int64(auth.ExpiresIn), 0)})
+	})
+}
+
+/*rawCommand calls any resource of the API with the given method, and prints the response*/
+func rawCommand(method string) command {
+
+	return func(c *cli, args []string) error {
+
+		withBody := method == http.MethodPost || method == http.MethodPut
+
+		if len(args) < 1 || len(args) > 2 || (!withBody && len(args) != 1) {
+			return errUsage
+		}
+
+		body := ""
+		if withBody {
+			if len(args) == 2 && args[1] != "-" {
+				body = args[1]
+			} else {
+				content, err := ioutil.ReadAll(c.stdin)
+				if err != nil {
+					return err
+				}
+				body = string(content)
+			}
+		}
+
+		return c.withClient(func(client *sdk.Client) error {
+
+			var resp *http.Response
+			var err error
+
+			switch method {
+			case http.MethodGet:
+				resp, err = client.Get(args[0])
+			case http.MethodPost:
+				resp, err = client.Post(args[0], body)
+			case http.MethodPut:
+				resp, err = client.Put(args[0], body)
+			case http.MethodDelete:
+				resp, err = client.Delete(args[0])
+			}
+
+			if err != nil {
+				return err
+			}
+
+			content, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			if err != nil {
+				return err
+			}
+
+			printBody(c.stdout, content)
+
+			if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+				return fmt.Errorf("API returned %s", resp.Status)
+			}
+
+			return nil
+		})
+	}
+}
+
+func itemsCommand(c *cli, args []string) error {
+
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "get":
+		if len(args) != 2 {
+			return errUsage
+		}
+
+		return c.withClient(func(client *sdk.Client) error {
+			item, err := client.GetItem(args[1])
+			if err != nil {
+				return err
+			}
+			return printJSON(c.stdout, item)
+		})
+
+	case "list":
+		flags := newFlagSet(c, "items list")
+		status := flags.String("status", "", "only the items with this status, i.e. active")
+
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		return c.withClient(func(client *sdk.Client) error {
+
+			ids, err := client.SearchAllItemIDs(*status)
+			if err != nil {
+				return err
+			}
+
+			items, err := client.GetItems(ids)
+			if err != nil {
+				return err
+			}
+
+			table := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(table, "ID\tTITLE\tPRICE\tAVAILABLE\tSTATUS")
+
+			for _, item := range items {
+				fmt.Fprintf(table, "%s\t%s\t%s\t%d\t%s\n", item.ID, item.Title, item.Price, item.AvailableQuantity, item.Status)
+			}
+
+			return table.Flush()
+		})
+	}
+
+	return errUsage
+}
+
+func ordersCommand(c *cli, args []string) error {
+
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "get":
+		if len(args) != 2 {
+			return errUsage
+		}
+
+		orderID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not an order id", args[1])
+		}
+
+		return c.withClient(func(client *sdk.Client) error {
+			order, err := client.GetOrder(orderID)
+			if err != nil {
+				return err
+			}
+			return printJSON(c.stdout, order)
+		})
+
+	case "list":
+		flags := newFlagSet(c, "orders list")
+		status := flags.String("status", "", "only the orders with this status, i.e. paid")
+
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		return c.withClient(func(client *sdk.Client) error {
+
+			orders, err := client.SearchAllOrders(*status)
+			if err != nil {
+				return err
+			}
+
+			table := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(table, "ID\tSTATUS\tBUYER\tTOTAL\tITEMS")
+
+			for _, order := range orders {
+
+				titles := make([]string, 0, len(order.OrderItems))
+				for _, orderItem := range order.OrderItems {
+					titles = append(titles, fmt.Sprintf("%dx %s", orderItem.Quantity, orderItem.Item.Title))
+				}
+
+				fmt.Fprintf(table, "%d\t%s\t%s\t%s\t%s\n", order.ID, order.Status, order.Buyer.Nickname, order.TotalAmount, strings.Join(titles, ", "))
+			}
+
+			return table.Flush()
+		})
+	}
+
+	return errUsage
+}
+
+func questionsCommand(c *cli, args []string) error {
+
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "list":
+		if len(args) != 2 {
+			return errUsage
+		}
+
+		return c.withClient(func(client *sdk.Client) error {
+
+			questions, err := client.GetQuestions(args[1])
+			if err != nil {
+				return err
+			}
+
+			table := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(table, "ID\tSTATUS\tQUESTION\tANSWER")
+
+			for _, question := range questions {
+
+				answer := ""
+				if question.Answer != nil {
+					answer = question.Answer.Text
+				}
+
+				fmt.Fprintf(table, "%d\t%s\t%s\t%s\n", question.ID, question.Status, question.Text, answer)
+			}
+
+			return table.Flush()
+		})
+
+	case "answer":
+		if len(args) != 3 {
+			return errUsage
+		}
+
+		questionID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a question id", args[1])
+		}
+
+		return c.withClient(func(client *sdk.Client) error {
+			question, err := client.AnswerQuestion(questionID, args[2])
+			if err != nil {
+				return err
+			}
+			return printJSON(c.stdout, question)
+		})
+	}
+
+	return errUsage
+}
+
+func printJSON(w io.Writer, v interface{}) error {
+
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", content)
+	return err
+}
+
+/*printBody pretty prints a JSON body, and prints any other body as it is*/
+func printBody(w io.Writer, body []byte) {
+
+	var indented bytes.Buffer
+	if json.Indent(&indented, body, "", "  ") != nil {
+		w.Write(body)
+		return
+	}
+
+	fmt.Fprintf(w, "%s\n", indented.Bytes())
+}
@@ -0,0 +1,116 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+)
+
+/*profile keeps the application credentials and the tokens of the user logged in*/
+type profile struct {
+	ClientID      int64              `json:"client_id"`
+	Secret        string             `json:"client_secret"`
+	CallBackURL   string             `json:"callback_url"`
+	SiteID        string             `json:"site_id"`
+	APIURL        string             `json:"api_url,omitempty"`
+	Authorization *sdk.Authorization `json:"authorization,omitempty"`
+}
+
+/*configFile keeps the profiles by name. It is written with permissions for the owner only, as it holds tokens.*/
+type configFile struct {
+	path     string
+	Profiles map[string]*profile `json:"profiles"`
+}
+
+/*defaultConfigPath returns $MELI_CONFIG, or meli/config.json within the user config directory*/
+func defaultConfigPath() string {
+
+	if path := os.Getenv("MELI_CONFIG"); path != "" {
+		return path
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = "."
+	}
+
+	return filepath.Join(dir, "meli", "config.json")
+}
+
+/*loadConfig reads the config file at path. A missing file is an empty config.*/
+func loadConfig(path string) (*configFile, error) {
+
+	config := &configFile{path: path, Profiles: make(map[string]*profile)}
+
+	content, err := ioutil.ReadFile(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("%s is not a valid config file: %s", path, err)
+	}
+
+	if config.Profiles == nil {
+		config.Profiles = make(map[string]*profile)
+	}
+
+	return config, nil
+}
+
+func (config *configFile) save() error {
+
+	content, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(config.path), 0700); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(config.path, content, 0600)
+}
+
+/*profile returns the profile with the given name, creating it if it does not exist*/
+func (config *configFile) profile(name string) *profile {
+
+	p, ok := config.Profiles[name]
+	if !ok {
+		p = &profile{SiteID: sdk.SiteMLA, CallBackURL: defaultCallBackURL}
+		config.Profiles[name] = p
+	}
+
+	return p
+}
+
+/*meliConfig returns the sdk config for the profile*/
+func (p *profile) meliConfig() sdk.MeliConfig {
+	return sdk.MeliConfig{ClientID: p.ClientID, Secret: p.Secret, CallBackURL: p.CallBackURL, APIURL: p.APIURL}
+}
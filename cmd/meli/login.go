@@ -0,0 +1,54 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+)
+
+const defaultCallBackURL = "http://localhost:8080/callback"
+
+/*openBrowser is called with the URL the user has to visit to log in. The URL is always printed as well.*/
+var openBrowser = func(authURL string) {}
+
+/*login waits for the user to authorize the application on the callback URL of the profile, and returns the tokens obtained*/
+func login(p *profile, timeout time.Duration, printf func(format string, args ...interface{})) (*sdk.Authorization, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client, err := sdk.LoopbackLogin(ctx, sdk.LoopbackConfig{
+		MeliConfig: p.meliConfig(),
+		SiteID:     p.SiteID,
+		Manager:    sdk.NewClientManager(sdk.ManagerConfig{}),
+		OpenURL: func(authURL string) error {
+			printf("Open the following URL to log in:\n\n%s\n\n", authURL)
+			openBrowser(authURL)
+			return nil
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	auth := client.Authorization()
+	return &auth, nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+meli is a command line tool to call MercadoLibre API on behalf of a user.
+
+Log in once per profile, providing the application credentials, and then call the API:
+
+	meli login -client-id 123456 -secret SECRET -site MLA
+	meli get /users/me
+	meli items list -status active
+	meli questions answer 123 "Yes, it is new"
+
+The credentials and tokens are kept within a config file, $MELI_CONFIG or meli/config.json in the user config directory.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+)
+
+const usage = `Usage: meli [-config FILE] [-profile NAME] COMMAND [ARGS]
+
+Commands:
+  login                        log in and store the tokens within the profile
+  token show|refresh           print or refresh the tokens of the profile
+  get PATH                     GET a resource and print the response
+  post|put PATH [BODY|-]       POST or PUT a JSON body, read from stdin when omitted or -
+  delete PATH                  DELETE a resource
+  items get ID|list            print an item, or the items of the user
+  orders get ID|list           print an order, or the orders sold by the user
+  questions list ITEM|answer ID TEXT
+                               print the questions about an item, or answer one
+
+Flags:
+`
+
+/*cli keeps what every command needs*/
+type cli struct {
+	stdin       io.Reader
+	stdout      io.Writer
+	stderr      io.Writer
+	config      *configFile
+	profileName string
+}
+
+type command func(c *cli, args []string) error
+
+var commands = map[string]command{
+	"login":     loginCommand,
+	"token":     tokenCommand,
+	"get":       rawCommand("GET"),
+	"post":      rawCommand("POST"),
+	"put":       rawCommand("PUT"),
+	"delete":    rawCommand("DELETE"),
+	"items":     itemsCommand,
+	"orders":    ordersCommand,
+	"questions": questionsCommand,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+/*run executes the command line given and returns the exit status*/
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+
+	defaultProfile := os.Getenv("MELI_PROFILE")
+	if defaultProfile == "" {
+		defaultProfile = "default"
+	}
+
+	flags := flag.NewFlagSet("meli", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configPath := flags.String("config", defaultConfigPath(), "config file keeping the profiles")
+	profileName := flags.String("profile", defaultProfile, "profile to use, $MELI_PROFILE by default")
+
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	cmd, ok := commands[flags.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "meli: unknown command %q, one of %v was expected\n", flags.Arg(0), commandNames())
+		return 2
+	}
+
+	config, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "meli: %s\n", err)
+		return 1
+	}
+
+	c := &cli{stdin: stdin, stdout: stdout, stderr: stderr, config: config, profileName: *profileName}
+
+	if err := cmd(c, flags.Args()[1:]); err != nil {
+		fmt.Fprintf(stderr, "meli: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+func commandNames() []string {
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func (c *cli) printf(format string, args ...interface{}) {
+	fmt.Fprintf(c.stderr, format, args...)
+}
+
+/*
+withClient calls f with a client acting on behalf of the user logged in within the profile.
+The tokens are stored again when they were refreshed, as refresh tokens can be used only once.
+*/
+func (c *cli) withClient(f func(client *sdk.Client) error) error {
+
+	p, ok := c.config.Profiles[c.profileName]
+	if !ok || p.Authorization == nil {
+		return fmt.Errorf("profile %q is not logged in, run meli login first", c.profileName)
+	}
+
+	client := sdk.RestoreClient(p.meliConfig(), *p.Authorization)
+
+	err := f(client)
+
+	if auth := client.Authorization(); auth != *p.Authorization {
+		p.Authorization = &auth
+		if saveErr := c.config.save(); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}
+
+	return err
+}
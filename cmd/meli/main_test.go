@@ -0,0 +1,194 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+const testSellerID = 214509008
+
+/*freeCallBackURL returns a callback URL on a local port which is not in use*/
+func freeCallBackURL(t *testing.T) string {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+	defer listener.Close()
+
+	return "http://" + listener.Addr().String() + "/callback"
+}
+
+/*newLoggedInConfig returns a config file with a profile logged in to the fake API*/
+func newLoggedInConfig(t *testing.T, server *sdktest.Server) string {
+
+	client, err := sdk.NewClientManager(sdk.ManagerConfig{}).Client(server.Config(server.NewCode(testSellerID)))
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	auth := client.Authorization()
+	config := &configFile{path: filepath.Join(t.TempDir(), "config.json"), Profiles: map[string]*profile{
+		"default": {ClientID: sdktest.ClientID, Secret: sdktest.ClientSecret, CallBackURL: sdktest.CallBackURL, SiteID: sdk.SiteMLA, APIURL: server.URL, Authorization: &auth},
+	}}
+
+	if err := config.save(); err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	return config.path
+}
+
+func Test_Login_stores_the_tokens_within_the_profile(t *testing.T) {
+
+	server := sdktest.NewServer()
+	defer server.Close()
+	server.AddUser(sdktest.User{ID: testSellerID, Nickname: "TEST_SELLER"})
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	config := &configFile{path: path, Profiles: map[string]*profile{"test": {SiteID: sdk.SiteMLA, APIURL: server.URL}}}
+	config.save()
+
+	//The browser redirects the user to the callback URL with a new code.
+	openBrowser = func(authURL string) {
+		parsed, _ := url.Parse(authURL)
+		go http.Get(parsed.Query().Get("redirect_uri") + "?" + url.Values{"code": {server.NewCode(testSellerID)}, "state": {parsed.Query().Get("state")}}.Encode())
+	}
+	defer func() { openBrowser = func(string) {} }()
+
+	var stderr bytes.Buffer
+	status := run([]string{"-config", path, "-profile", "test", "login", "-client-id", "123456", "-secret", sdktest.ClientSecret,
+		"-callback", freeCallBackURL(t), "-timeout", "5s"}, nil, &bytes.Buffer{}, &stderr)
+
+	if status != 0 {
+		log.Printf("Error: login failed %s", stderr.String())
+		t.FailNow()
+	}
+
+	config, _ = loadConfig(path)
+
+	if auth := config.Profiles["test"].Authorization; auth == nil || auth.UserID != testSellerID || auth.RefreshToken == "" {
+		log.Printf("Error: tokens were not stored %+v", auth)
+		t.FailNow()
+	}
+}
+
+func Test_Raw_calls_print_pretty_JSON_and_fail_on_API_errors(t *testing.T) {
+
+	server := sdktest.NewServer()
+	defer server.Close()
+	server.AddUser(sdktest.User{ID: testSellerID, Nickname: "TEST_SELLER"})
+
+	path := newLoggedInConfig(t, server)
+
+	var stdout bytes.Buffer
+	if status := run([]string{"-config", path, "get", "/users/me"}, nil, &stdout, &bytes.Buffer{}); status != 0 {
+		log.Printf("Error: get failed with status %d", status)
+		t.FailNow()
+	}
+
+	if !strings.Contains(stdout.String(), "\n  \"nickname\": \"TEST_SELLER\"") {
+		log.Printf("Error: response was not pretty printed %s", stdout.String())
+		t.FailNow()
+	}
+
+	stdout.Reset()
+	status := run([]string{"-config", path, "post", "/items"}, strings.NewReader("{\"title\":\"Item\",\"price\":10}"), &stdout, &bytes.Buffer{})
+
+	var item map[string]interface{}
+	if status != 0 || json.Unmarshal(stdout.Bytes(), &item) != nil || item["title"] != "Item" {
+		log.Printf("Error: item was not posted from stdin %s", stdout.String())
+		t.FailNow()
+	}
+
+	if status := run([]string{"-config", path, "get", "/items/MLA404"}, nil, &bytes.Buffer{}, &bytes.Buffer{}); status != 1 {
+		log.Printf("Error: expected exit status 1 obtained %d", status)
+		t.FailNow()
+	}
+}
+
+func Test_Refreshed_tokens_are_stored_again(t *testing.T) {
+
+	server := sdktest.NewServer()
+	defer server.Close()
+	server.AddUser(sdktest.User{ID: testSellerID, Nickname: "TEST_SELLER"})
+
+	//Tokens expiring within a minute are refreshed on every call, and refresh tokens can be used only once.
+	server.SetTokenTTL(30 * time.Second)
+
+	path := newLoggedInConfig(t, server)
+
+	for i := 0; i < 2; i++ {
+		var stderr bytes.Buffer
+		if status := run([]string{"-config", path, "get", "/users/me"}, nil, &bytes.Buffer{}, &stderr); status != 0 {
+			log.Printf("Error: call %d failed %s", i, stderr.String())
+			t.FailNow()
+		}
+	}
+}
+
+func Test_Typed_commands_print_items_orders_and_questions(t *testing.T) {
+
+	server := sdktest.NewServer()
+	defer server.Close()
+	server.AddUser(sdktest.User{ID: testSellerID, Nickname: "TEST_SELLER"})
+	server.AddItem(sdktest.Object{"id": "MLA1", "seller_id": testSellerID, "title": "Item de test", "price": 10, "currency_id": "ARS", "status": "active"})
+	server.AddOrder(sdktest.Object{"id": 2000001, "status": "paid", "seller": sdktest.Object{"id": testSellerID}, "buyer": sdktest.Object{"id": 1, "nickname": "TEST_BUYER"}})
+	question := server.AddQuestion(sdktest.Object{"item_id": "MLA1", "seller_id": testSellerID, "text": "Is it new?", "status": "UNANSWERED"})
+
+	path := newLoggedInConfig(t, server)
+
+	expected := map[string]string{
+		"items list":               "Item de test",
+		"orders list -status paid": "TEST_BUYER",
+		"questions list MLA1":      "Is it new?",
+	}
+
+	for commandLine, output := range expected {
+
+		var stdout, stderr bytes.Buffer
+		args := append([]string{"-config", path}, strings.Fields(commandLine)...)
+
+		if status := run(args, nil, &stdout, &stderr); status != 0 || !strings.Contains(stdout.String(), output) {
+			log.Printf("Error: %s printed %q %s", commandLine, stdout.String(), stderr.String())
+			t.FailNow()
+		}
+	}
+
+	args := []string{"-config", path, "questions", "answer", fmt.Sprint(question["id"]), "Yes"}
+	if status := run(args, nil, &bytes.Buffer{}, &bytes.Buffer{}); status != 0 {
+		log.Printf("Error: question was not answered")
+		t.FailNow()
+	}
+}
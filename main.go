@@ -139,7 +139,7 @@ func getItem(w http.ResponseWriter, r *http.Request) {
 
 	var response *http.Response
 	if response, err = client.Get(resource); err != nil {
-		log.Printf("Error: ", err.Error())
+		log.Printf("Error: %s", err.Error())
 		return
 	}
 
@@ -163,7 +163,7 @@ func postItem(w http.ResponseWriter, r *http.Request) {
 	response, err := client.Post("/items/", item)
 
 	if err != nil {
-		log.Printf("Error: ", err)
+		log.Printf("Error: %s", err)
 		return
 	}
 	printOutput(w, response)
@@ -181,7 +181,7 @@ func getSites(w http.ResponseWriter, r *http.Request) {
 
 	var response *http.Response
 	if response, err = client.Get(resource); err != nil {
-		log.Printf("Error: ", err.Error())
+		log.Printf("Error: %s", err.Error())
 		return
 	}
 
@@ -200,7 +200,7 @@ func me(w http.ResponseWriter, r *http.Request) {
 	client, err := sdk.Meli(clientID, code, clientSecret, redirectURL)
 
 	if err != nil {
-		log.Printf("Error: ", err.Error())
+		log.Printf("Error: %s", err.Error())
 		return
 	}
 
@@ -212,13 +212,17 @@ func me(w http.ResponseWriter, r *http.Request) {
 
 	var response *http.Response
 	if response, err = client.Get("/users/me"); err != nil {
-		log.Printf("Error: ", err.Error())
+		log.Printf("Error: %s", err.Error())
 		return
 	}
 
 	if response.StatusCode == http.StatusForbidden {
 
-		url := sdk.GetAuthURL(clientID, sdk.AuthURLMLA, host+"/"+user+"/users/me")
+		url, err := sdk.GetAuthURLForSite(clientID, getSiteID(r), host+"/"+user+"/users/me")
+		if err != nil {
+			log.Printf("Error: %s", err.Error())
+			return
+		}
 		log.Printf("Returning Authentication URL:%s\n", url)
 
 		//		userForbidden[user] = ""
@@ -247,13 +251,13 @@ func addresses(w http.ResponseWriter, r *http.Request) {
 	client, err := sdk.Meli(clientID, code, clientSecret, redirectURL)
 
 	if err != nil {
-		log.Printf("Error: ", err.Error())
+		log.Printf("Error: %s", err.Error())
 		return
 	}
 
 	var response *http.Response
 	if response, err = client.Get(resource); err != nil {
-		log.Printf("Error: ", err.Error())
+		log.Printf("Error: %s", err.Error())
 		return
 	}
 
@@ -263,7 +267,11 @@ func addresses(w http.ResponseWriter, r *http.Request) {
 	  entering your credentials you will obtain a CODE which will be used to get all the authorization tokens.
 	*/
 	if response.StatusCode == http.StatusForbidden {
-		url := sdk.GetAuthURL(clientID, sdk.AuthURLMLA, redirectURL)
+		url, err := sdk.GetAuthURLForSite(clientID, getSiteID(r), redirectURL)
+		if err != nil {
+			log.Printf("Error: %s", err.Error())
+			return
+		}
 		body, _ := ioutil.ReadAll(response.Body)
 		log.Printf("Returning Authentication URL:%s\n", url)
 		log.Printf("Error:%s", body)
@@ -294,6 +302,20 @@ func getUserCode(r *http.Request) string {
 	return code
 }
 
+/**
+This method returns the site the user authenticates in, which can be sent as the site query param. MLA is the default one.
+*/
+func getSiteID(r *http.Request) string {
+
+	site := r.FormValue("site")
+
+	if strings.Compare(site, "") == 0 {
+		return sdk.SiteMLA
+	}
+
+	return site
+}
+
 func getParam(r *http.Request, param string) string {
 
 	pathParams := mux.Vars(r)
@@ -0,0 +1,256 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+/*MaxAdsDateRange is the longest period ad metrics can be queried for*/
+const MaxAdsDateRange = 90 * 24 * time.Hour
+
+/*adMetrics are the metrics requested for campaigns and ads*/
+const adMetrics = "clicks,prints,ctr,cost,cpc,acos,units_quantity,total_amount"
+
+/*ErrAdsDateRange is returned when the dates of an AdsQuery are missing, reversed or too far apart*/
+var ErrAdsDateRange = errors.New("ads: date_from and date_to are required and can be up to 90 days apart")
+
+/*Advertiser is the Product Ads account of a user in a site*/
+type Advertiser struct {
+	ID     int64  `json:"advertiser_id"`
+	SiteID string `json:"site_id"`
+	Name   string `json:"advertiser_name"`
+}
+
+/*
+AdMetrics is the performance of ads within a period. CTR and ACOS are percentages: clicks over prints
+and cost over the amount sold.
+*/
+type AdMetrics struct {
+	Clicks        int     `json:"clicks"`
+	Prints        int     `json:"prints"`
+	CTR           float64 `json:"ctr"`
+	Cost          float64 `json:"cost"`
+	CPC           float64 `json:"cpc"`
+	ACOS          float64 `json:"acos"`
+	UnitsQuantity int     `json:"units_quantity"`
+	TotalAmount   float64 `json:"total_amount"`
+}
+
+/*Add returns the sum of both metrics, computing CTR, CPC and ACOS again from the totals*/
+func (metrics AdMetrics) Add(other AdMetrics) AdMetrics {
+
+	sum := AdMetrics{
+		Clicks:        metrics.Clicks + other.Clicks,
+		Prints:        metrics.Prints + other.Prints,
+		Cost:          roundCents(metrics.Cost + other.Cost),
+		UnitsQuantity: metrics.UnitsQuantity + other.UnitsQuantity,
+		TotalAmount:   roundCents(metrics.TotalAmount + other.TotalAmount),
+	}
+
+	if sum.Prints > 0 {
+		sum.CTR = roundCents(float64(sum.Clicks) / float64(sum.Prints) * 100)
+	}
+	if sum.Clicks > 0 {
+		sum.CPC = roundCents(sum.Cost / float64(sum.Clicks))
+	}
+	if sum.TotalAmount > 0 {
+		sum.ACOS = roundCents(sum.Cost / sum.TotalAmount * 100)
+	}
+
+	return sum
+}
+
+/*Campaign is a Product Ads campaign, along with its metrics within the queried period*/
+type Campaign struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Status     string    `json:"status"`
+	Budget     float64   `json:"budget"`
+	ACOSTarget float64   `json:"acos_target"`
+	Metrics    AdMetrics `json:"metrics"`
+}
+
+/*Ad is an item advertised within a campaign, along with its metrics within the queried period*/
+type Ad struct {
+	ItemID     string    `json:"item_id"`
+	CampaignID int64     `json:"campaign_id"`
+	Title      string    `json:"title"`
+	Status     string    `json:"status"`
+	Metrics    AdMetrics `json:"metrics"`
+}
+
+/*DailyMetrics are the metrics of a single day, formatted as 2006-01-02*/
+type DailyMetrics struct {
+	Date string `json:"date"`
+	AdMetrics
+}
+
+/*AdsQuery is the period, both days included, and the page of campaigns or ads to read*/
+type AdsQuery struct {
+	DateFrom time.Time
+	DateTo   time.Time
+	Offset   int
+	//Limit is the size of the page. It defaults to 50.
+	Limit int
+}
+
+func (query AdsQuery) params() (url.Values, error) {
+
+	if query.DateFrom.IsZero() || query.DateTo.IsZero() || query.DateTo.Before(query.DateFrom) ||
+		query.DateTo.Sub(query.DateFrom) > MaxAdsDateRange {
+		return nil, ErrAdsDateRange
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	params := url.Values{}
+	params.Set("date_from", query.DateFrom.Format("2006-01-02"))
+	params.Set("date_to", query.DateTo.Format("2006-01-02"))
+	params.Set("metrics", adMetrics)
+	params.Set("offset", strconv.Itoa(query.Offset))
+	params.Set("limit", strconv.Itoa(limit))
+
+	return params, nil
+}
+
+/*GetAdvertisers returns the Product Ads accounts of the user the client acts on behalf of*/
+func (client *Client) GetAdvertisers() ([]Advertiser, error) {
+
+	var result struct {
+		Advertisers []Advertiser `json:"advertisers"`
+	}
+
+	if err := client.getJSON("/advertising/advertisers?product_id=PADS", &result); err != nil {
+		return nil, err
+	}
+
+	return result.Advertisers, nil
+}
+
+/*GetCampaigns returns a page of the campaigns of an advertiser, with their metrics within the period*/
+func (client *Client) GetCampaigns(advertiserID int64, query AdsQuery) ([]Campaign, Paging, error) {
+
+	params, err := query.params()
+	if err != nil {
+		return nil, Paging{}, err
+	}
+
+	var result struct {
+		Results []Campaign `json:"results"`
+		Paging  Paging     `json:"paging"`
+	}
+
+	if err := client.getJSON(advertiserPath(advertiserID)+"/campaigns?"+params.Encode(), &result); err != nil {
+		return nil, Paging{}, err
+	}
+
+	return result.Results, result.Paging, nil
+}
+
+/*GetAllCampaigns returns every campaign of an advertiser, reading one page after the other*/
+func (client *Client) GetAllCampaigns(advertiserID int64, query AdsQuery) ([]Campaign, error) {
+
+	var campaigns []Campaign
+
+	for {
+		page, paging, err := client.GetCampaigns(advertiserID, query)
+		if err != nil {
+			return nil, err
+		}
+
+		campaigns = append(campaigns, page...)
+		query.Offset += len(page)
+
+		if len(page) == 0 || query.Offset >= paging.Total {
+			return campaigns, nil
+		}
+	}
+}
+
+/*GetCampaignDailyMetrics returns the metrics of a campaign for every day of the period*/
+func (client *Client) GetCampaignDailyMetrics(campaignID int64, from time.Time, to time.Time) ([]DailyMetrics, error) {
+
+	params, err := AdsQuery{DateFrom: from, DateTo: to}.params()
+	if err != nil {
+		return nil, err
+	}
+
+	params.Del("offset")
+	params.Del("limit")
+	params.Set("aggregation_type", "DAILY")
+
+	var result struct {
+		Results []DailyMetrics `json:"results"`
+	}
+
+	if err := client.getJSON("/advertising/product_ads/campaigns/"+strconv.FormatInt(campaignID, 10)+"?"+params.Encode(), &result); err != nil {
+		return nil, err
+	}
+
+	return result.Results, nil
+}
+
+/*GetAds returns a page of the ads of an advertiser, with their metrics within the period*/
+func (client *Client) GetAds(advertiserID int64, query AdsQuery) ([]Ad, Paging, error) {
+
+	params, err := query.params()
+	if err != nil {
+		return nil, Paging{}, err
+	}
+
+	var result struct {
+		Results []Ad   `json:"results"`
+		Paging  Paging `json:"paging"`
+	}
+
+	if err := client.getJSON(advertiserPath(advertiserID)+"/ads/search?"+params.Encode(), &result); err != nil {
+		return nil, Paging{}, err
+	}
+
+	return result.Results, result.Paging, nil
+}
+
+/*GetAllAds returns every ad of an advertiser, reading one page after the other*/
+func (client *Client) GetAllAds(advertiserID int64, query AdsQuery) ([]Ad, error) {
+
+	var ads []Ad
+
+	for {
+		page, paging, err := client.GetAds(advertiserID, query)
+		if err != nil {
+			return nil, err
+		}
+
+		ads = append(ads, page...)
+		query.Offset += len(page)
+
+		if len(page) == 0 || query.Offset >= paging.Total {
+			return ads, nil
+		}
+	}
+}
+
+func advertiserPath(advertiserID int64) string {
+	return "/advertising/advertisers/" + strconv.FormatInt(advertiserID, 10) + "/product_ads"
+}
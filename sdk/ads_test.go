@@ -0,0 +1,146 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+func Test_Campaigns_and_ads_are_read_with_their_metrics(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	server.AddAdvertiser(testSellerID, sdktest.Object{"advertiser_id": 10, "site_id": "MLA", "advertiser_name": "Seller"})
+	server.AddAdvertiser(99, sdktest.Object{"advertiser_id": 11})
+	server.AddCampaign(10, sdktest.Object{"id": 1, "name": "Summer", "status": "active", "budget": 1000, "acos_target": 15})
+	server.AddAd(10, sdktest.Object{"item_id": "MLA1", "campaign_id": 1, "title": "Item 1", "status": "active"})
+	server.AddAd(10, sdktest.Object{"item_id": "MLA2", "campaign_id": 1, "title": "Item 2", "status": "active"})
+	server.AddAdMetrics("MLA1", day, sdktest.Object{"clicks": 10, "prints": 100, "cost": 20, "units_quantity": 1, "total_amount": 200})
+	server.AddAdMetrics("MLA2", day.AddDate(0, 0, 1), sdktest.Object{"clicks": 30, "prints": 300, "cost": 60, "units_quantity": 2, "total_amount": 200})
+	server.AddAdMetrics("MLA2", day.AddDate(0, 0, 10), sdktest.Object{"clicks": 100, "prints": 1000})
+
+	advertisers, err := client.GetAdvertisers()
+
+	if err != nil || len(advertisers) != 1 || advertisers[0].ID != 10 || advertisers[0].Name != "Seller" {
+		log.Printf("Error: unexpected advertisers %+v %v", advertisers, err)
+		t.FailNow()
+	}
+
+	query := sdk.AdsQuery{DateFrom: day, DateTo: day.AddDate(0, 0, 6)}
+
+	campaigns, paging, err := client.GetCampaigns(10, query)
+
+	if err != nil || len(campaigns) != 1 || paging.Total != 1 {
+		log.Printf("Error: unexpected campaigns %+v %v", campaigns, err)
+		t.FailNow()
+	}
+
+	expected := sdk.AdMetrics{Clicks: 40, Prints: 400, CTR: 10, Cost: 80, CPC: 2, ACOS: 20, UnitsQuantity: 3, TotalAmount: 400}
+	if campaigns[0].Metrics != expected || campaigns[0].ACOSTarget != 15 {
+		log.Printf("Error: unexpected campaign metrics %+v", campaigns[0])
+		t.FailNow()
+	}
+
+	daily, err := client.GetCampaignDailyMetrics(1, day, day.AddDate(0, 0, 6))
+
+	if err != nil || len(daily) != 7 || daily[0].Date != "2026-10-01" || daily[0].Clicks != 10 || daily[1].ACOS != 30 {
+		log.Printf("Error: unexpected daily metrics %+v %v", daily, err)
+		t.FailNow()
+	}
+
+	sum := daily[0].AdMetrics
+	for _, metrics := range daily[1:] {
+		sum = sum.Add(metrics.AdMetrics)
+	}
+
+	if sum != expected {
+		log.Printf("Error: unexpected sum of daily metrics %+v", sum)
+		t.FailNow()
+	}
+
+	ads, _, err := client.GetAds(10, query)
+
+	if err != nil || len(ads) != 2 || ads[1].ItemID != "MLA2" || ads[1].Metrics.Clicks != 30 || ads[1].CampaignID != 1 {
+		log.Printf("Error: unexpected ads %+v %v", ads, err)
+		t.FailNow()
+	}
+
+	if _, _, err := client.GetCampaigns(11, query); err == nil {
+		log.Printf("Error: campaigns of another advertiser were read")
+		t.FailNow()
+	}
+}
+
+func Test_Every_ad_is_read_page_by_page(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.AddAdvertiser(testSellerID, sdktest.Object{"advertiser_id": 10})
+	for i := 1; i <= 25; i++ {
+		server.AddAd(10, sdktest.Object{"item_id": fmt.Sprintf("MLA%02d", i), "campaign_id": 1})
+	}
+
+	now := time.Now()
+	ads, err := client.GetAllAds(10, sdk.AdsQuery{DateFrom: now.AddDate(0, 0, -30), DateTo: now, Limit: 10})
+
+	if err != nil || len(ads) != 25 || ads[24].ItemID != "MLA25" {
+		log.Printf("Error: unexpected ads %d %v", len(ads), err)
+		t.FailNow()
+	}
+
+	pages := 0
+	for _, request := range server.Requests() {
+		if request.Query.Get("metrics") != "" {
+			pages++
+		}
+	}
+
+	if pages != 3 {
+		log.Printf("Error: expected 3 pages obtained %d", pages)
+		t.FailNow()
+	}
+}
+
+func Test_Ads_date_range_is_validated(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	now := time.Now()
+
+	for _, query := range []sdk.AdsQuery{
+		{},
+		{DateFrom: now, DateTo: now.AddDate(0, 0, -1)},
+		{DateFrom: now.AddDate(0, 0, -91), DateTo: now},
+	} {
+		if _, _, err := client.GetCampaigns(10, query); !errors.Is(err, sdk.ErrAdsDateRange) {
+			log.Printf("Error: expected ErrAdsDateRange for %+v obtained %v", query, err)
+			t.FailNow()
+		}
+	}
+}
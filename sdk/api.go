@@ -0,0 +1,56 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+/*
+decodeResponse checks the status code of the given response and decodes its JSON body into v, if v is not nil.
+The body is always closed.
+*/
+func decodeResponse(resp *http.Response, v interface{}) error {
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return newAPIError(resp)
+	}
+
+	if resp.Body == nil {
+		return nil
+	}
+
+	defer resp.Body.Close()
+
+	if v == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+/*getJSON performs a GET over the given resource and decodes the response into v*/
+func (client *Client) getJSON(resourcePath string, v interface{}) error {
+
+	resp, err := client.Get(resourcePath)
+	if err != nil {
+		return err
+	}
+
+	return decodeResponse(resp, v)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+/*BillingInfo is the fiscal data of the buyer of an order, i.e. its CPF or CNPJ in Brazil or its RFC in Mexico*/
+type BillingInfo struct {
+	DocType        string             `json:"doc_type"`
+	DocNumber      string             `json:"doc_number"`
+	AdditionalInfo []BillingInfoField `json:"additional_info"`
+}
+
+/*BillingInfoField is an extra piece of fiscal data, i.e. {"type": "STREET_NAME", "value": "Rua Augusta"}*/
+type BillingInfoField struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+/*Get returns the value of the additional info of the given type, or an empty string if there is none*/
+func (info BillingInfo) Get(fieldType string) string {
+
+	for _, field := range info.AdditionalInfo {
+		if field.Type == fieldType {
+			return field.Value
+		}
+	}
+
+	return ""
+}
+
+/*Invoice is the invoice issued for an order*/
+type Invoice struct {
+	ID            int64      `json:"id"`
+	Status        string     `json:"status"`
+	FiscalKey     string     `json:"fiscal_key"`
+	InvoiceNumber int64      `json:"invoice_number"`
+	InvoiceSeries int        `json:"invoice_series"`
+	Amount        float64    `json:"amount"`
+	IssuedDate    *time.Time `json:"issued_date,omitempty"`
+}
+
+/*GetBillingInfo returns the fiscal data of the buyer of an order*/
+func (client *Client) GetBillingInfo(orderID int64) (*BillingInfo, error) {
+
+	var result struct {
+		BillingInfo BillingInfo `json:"billing_info"`
+	}
+
+	if err := client.getJSON("/orders/"+strconv.FormatInt(orderID, 10)+"/billing_info", &result); err != nil {
+		return nil, err
+	}
+
+	return &result.BillingInfo, nil
+}
+
+/*GetOrderInvoice returns the invoice issued for an order sold by the user the client acts on behalf of*/
+func (client *Client) GetOrderInvoice(orderID int64) (*Invoice, error) {
+
+	invoice := new(Invoice)
+	resource := "/users/" + strconv.FormatInt(client.UserID(), 10) + "/invoices/orders/" + strconv.FormatInt(orderID, 10)
+
+	if err := client.getJSON(resource, invoice); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+/*
+UploadInvoice uploads the XML of the invoice issued for an order, and returns the ids of the fiscal documents created.
+Invoices are attached to the pack of the order, or to the order itself when it has no pack.
+*/
+func (client *Client) UploadInvoice(order *Order, xml File) ([]string, error) {
+
+	xml.Field = "fiscal_document"
+	if xml.ContentType == "" {
+		xml.ContentType = "application/xml"
+	}
+
+	var result struct {
+		IDs []string `json:"ids"`
+	}
+
+	if err := client.postMultipart(fiscalDocumentsPath(order.packID()), nil, []File{xml}, &result); err != nil {
+		return nil, err
+	}
+
+	return result.IDs, nil
+}
+
+/*GetFiscalDocument returns the content of a fiscal document uploaded to a pack, i.e. the XML of an invoice*/
+func (client *Client) GetFiscalDocument(packID int64, documentID string) ([]byte, error) {
+
+	resp, err := client.Get(fiscalDocumentsPath(packID) + "/" + url.PathEscape(documentID))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, newAPIError(resp)
+	}
+
+	defer resp.Body.Close()
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+func fiscalDocumentsPath(packID int64) string {
+	return "/packs/" + strconv.FormatInt(packID, 10) + "/fiscal_documents"
+}
@@ -0,0 +1,101 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+func Test_Billing_info_and_invoice_of_an_order_are_read(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.AddOrder(sdktest.Object{"id": 2000001, "status": "paid", "seller": sdktest.Object{"id": testSellerID}})
+	server.SetBillingInfo(2000001, sdktest.Object{"doc_type": "CPF", "doc_number": "12345678909",
+		"additional_info": []sdktest.Object{{"type": "FIRST_NAME", "value": "Maria"}, {"type": "STATE_NAME", "value": "São Paulo"}}})
+	server.SetOrderInvoice(2000001, sdktest.Object{"id": 10, "status": "authorized", "fiscal_key": "3519", "invoice_number": 123,
+		"invoice_series": 1, "amount": 100.5, "issued_date": "2026-10-01T10:00:00.000-03:00"})
+
+	billingInfo, err := client.GetBillingInfo(2000001)
+
+	if err != nil || billingInfo.DocType != "CPF" || billingInfo.DocNumber != "12345678909" || billingInfo.Get("STATE_NAME") != "São Paulo" ||
+		billingInfo.Get("LAST_NAME") != "" {
+		log.Printf("Error: unexpected billing info %+v %v", billingInfo, err)
+		t.FailNow()
+	}
+
+	invoice, err := client.GetOrderInvoice(2000001)
+
+	if err != nil || invoice.Status != "authorized" || invoice.InvoiceNumber != 123 || invoice.Amount != 100.5 || invoice.IssuedDate == nil {
+		log.Printf("Error: unexpected invoice %+v %v", invoice, err)
+		t.FailNow()
+	}
+
+	if _, err := client.GetOrderInvoice(2000002); err == nil {
+		log.Printf("Error: expected an error for an unknown order")
+		t.FailNow()
+	}
+}
+
+func Test_Invoice_XML_is_uploaded_to_the_pack_of_the_order(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.AddOrder(sdktest.Object{"id": 2000001, "pack_id": 3000001, "seller": sdktest.Object{"id": testSellerID}})
+	server.AddOrder(sdktest.Object{"id": 2000002, "seller": sdktest.Object{"id": testSellerID}})
+
+	xml := "<?xml version=\"1.0\"?><nfeProc></nfeProc>"
+
+	packed, _ := client.GetOrder(2000001)
+	ids, err := client.UploadInvoice(packed, sdk.File{Name: "invoice.xml", Content: strings.NewReader(xml)})
+
+	if err != nil || len(ids) != 1 {
+		log.Printf("Error: invoice was not uploaded %v %v", ids, err)
+		t.FailNow()
+	}
+
+	document, ok := server.FiscalDocuments(3000001)[ids[0]]
+	if !ok || document.Name != "invoice.xml" || document.ContentType != "application/xml" {
+		log.Printf("Error: unexpected fiscal document %+v", document)
+		t.FailNow()
+	}
+
+	content, err := client.GetFiscalDocument(3000001, ids[0])
+	if err != nil || string(content) != xml {
+		log.Printf("Error: unexpected fiscal document content %s %v", content, err)
+		t.FailNow()
+	}
+
+	single, _ := client.GetOrder(2000002)
+	if ids, err := client.UploadInvoice(single, sdk.File{Name: "invoice.xml", Content: strings.NewReader(xml)}); err != nil ||
+		len(server.FiscalDocuments(2000002)) != 1 || len(ids) != 1 {
+		log.Printf("Error: invoice of an order without pack was not uploaded to the order %v", err)
+		t.FailNow()
+	}
+
+	if _, err := client.GetFiscalDocument(3000001, "unknown"); err == nil {
+		log.Printf("Error: expected an error for an unknown fiscal document")
+		t.FailNow()
+	}
+}
@@ -0,0 +1,423 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	BulkUpdated = "updated"
+	BulkFailed  = "failed"
+)
+
+/*maxRetryBackoff is the longest wait between retries, however many were made*/
+const maxRetryBackoff = 30 * time.Second
+
+/*ItemPatch holds the changes to be applied to an item, i.e. {"price": 10} or {"available_quantity": 5}*/
+type ItemPatch struct {
+	ItemID  string
+	Changes map[string]interface{}
+}
+
+type BulkConfig struct {
+	//Concurrency is how many items are updated at the same time. It defaults to 4.
+	Concurrency int
+	//RequestsPerSecond limits the calls to the API, retries included. Zero means no limit.
+	RequestsPerSecond float64
+	//MaxRetries is how many times a transient failure is retried: network errors, 429 and 5xx. It defaults to 3, use -1 for none.
+	MaxRetries int
+	//RetryBackoff is the wait before the first retry, which doubles on each one up to maxRetryBackoff. It defaults to one second.
+	//A Retry-After header returned by the API takes precedence.
+	RetryBackoff time.Duration
+}
+
+/*BulkResult is the outcome of applying an ItemPatch*/
+type BulkResult struct {
+	ItemID     string        `json:"item_id"`
+	Status     string        `json:"status"`
+	StatusCode int           `json:"status_code,omitempty"`
+	Attempts   int           `json:"attempts"`
+	Duration   time.Duration `json:"-"`
+	Err        error         `json:"-"`
+	Causes     []ErrorCause  `json:"causes,omitempty"`
+}
+
+func (result BulkResult) MarshalJSON() ([]byte, error) {
+
+	type plain BulkResult
+
+	errorMessage := ""
+	if result.Err != nil {
+		errorMessage = result.Err.Error()
+	}
+
+	return json.Marshal(struct {
+		plain
+		DurationMillis int64  `json:"duration_ms"`
+		Error          string `json:"error,omitempty"`
+	}{plain(result), result.Duration.Milliseconds(), errorMessage})
+}
+
+/*BulkReport is the result of every patch applied by a BulkUpdater*/
+type BulkReport []BulkResult
+
+/*Failed returns the results of the patches which could not be applied*/
+func (report BulkReport) Failed() BulkReport {
+
+	var failed BulkReport
+	for _, result := range report {
+		if result.Status != BulkUpdated {
+			failed = append(failed, result)
+		}
+	}
+
+	return failed
+}
+
+/*WriteJSON writes the report as a JSON array*/
+func (report BulkReport) WriteJSON(w io.Writer) error {
+
+	if report == nil {
+		report = BulkReport{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
+
+/*WriteCSV writes the report as CSV, with a header. The API error causes are written as "code: message" separated by ";".*/
+func (report BulkReport) WriteCSV(w io.Writer) error {
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"item_id", "status", "status_code", "attempts", "duration_ms", "error", "causes"})
+
+	for _, result := range report {
+
+		errorMessage := ""
+		if result.Err != nil {
+			errorMessage = result.Err.Error()
+		}
+
+		causes := make([]string, 0, len(result.Causes))
+		for _, cause := range result.Causes {
+			causes = append(causes, strings.TrimPrefix(cause.Code+": "+cause.Message, ": "))
+		}
+
+		writer.Write([]string{
+			result.ItemID,
+			result.Status,
+			strconv.Itoa(result.StatusCode),
+			strconv.Itoa(result.Attempts),
+			strconv.FormatInt(result.Duration.Milliseconds(), 10),
+			errorMessage,
+			strings.Join(causes, "; "),
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+/*
+RateLimitRecorder can be implemented by an Instrumentation which wants to know how long
+the SDK waited because of rate limiting.
+*/
+type RateLimitRecorder interface {
+	RateLimitWaited(resourcePath string, wait time.Duration)
+}
+
+/*
+BulkUpdater applies item patches with bounded concurrency and rate limiting, retrying transient failures.
+If the client Instrumentation implements RateLimitRecorder, it is told how long every call waited for the rate limit.
+*/
+type BulkUpdater struct {
+	client  *Client
+	config  BulkConfig
+	limiter *rateLimiter
+}
+
+/*NewBulkUpdater returns a BulkUpdater which updates the items through the given client*/
+func NewBulkUpdater(client *Client, config BulkConfig) *BulkUpdater {
+
+	if config.Concurrency <= 0 {
+		config.Concurrency = 4
+	}
+
+	if config.MaxRetries == 0 {
+		config.MaxRetries = 3
+	} else if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = time.Second
+	}
+
+	return &BulkUpdater{client: client, config: config, limiter: newRateLimiter(config.RequestsPerSecond)}
+}
+
+/*
+Run applies the patches received until the channel is closed, and sends the result of each one, in the order
+they finish. The results channel is closed once every patch was applied. If ctx is done, the workers stop as soon
+as their results are not read, so the results channel is closed even if the consumer stopped reading it.
+*/
+func (updater *BulkUpdater) Run(ctx context.Context, patches <-chan ItemPatch) <-chan BulkResult {
+
+	results := make(chan BulkResult)
+
+	var workers sync.WaitGroup
+	workers.Add(updater.config.Concurrency)
+
+	for i := 0; i < updater.config.Concurrency; i++ {
+		go func() {
+			defer workers.Done()
+			for patch := range patches {
+				select {
+				case results <- updater.apply(ctx, patch):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+/*UpdateAll applies every patch and returns the report in the same order as the patches*/
+func (updater *BulkUpdater) UpdateAll(ctx context.Context, patches []ItemPatch) BulkReport {
+
+	type indexed struct {
+		index  int
+		result BulkResult
+	}
+
+	jobs := make(chan int)
+	done := make(chan indexed)
+
+	var workers sync.WaitGroup
+	workers.Add(updater.config.Concurrency)
+
+	for i := 0; i < updater.config.Concurrency; i++ {
+		go func() {
+			defer workers.Done()
+			for index := range jobs {
+				done <- indexed{index, updater.apply(ctx, patches[index])}
+			}
+		}()
+	}
+
+	go func() {
+		for index := range patches {
+			jobs <- index
+		}
+		close(jobs)
+		workers.Wait()
+		close(done)
+	}()
+
+	report := make(BulkReport, len(patches))
+	for result := range done {
+		report[result.index] = result.result
+	}
+
+	return report
+}
+
+/*apply updates a single item, retrying transient failures*/
+func (updater *BulkUpdater) apply(ctx context.Context, patch ItemPatch) BulkResult {
+
+	start := time.Now()
+	result := BulkResult{ItemID: patch.ItemID, Status: BulkFailed}
+	resourcePath := "/items/" + url.PathEscape(patch.ItemID)
+
+	body, err := json.Marshal(patch.Changes)
+	if err != nil {
+		result.Err = err
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	backoff := updater.config.RetryBackoff
+
+	for {
+		if err := updater.wait(ctx, resourcePath, 0); err != nil {
+			result.Err = err
+			break
+		}
+
+		result.Attempts++
+
+		resp, err := callAPI(updater.client, resourcePath, HTTPPut{httpClient: updater.client.httpClient, body: string(body)}, result.Attempts-1)
+		retryAfter, transient := backoff, true
+
+		if err == nil {
+			result.StatusCode = resp.StatusCode
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), backoff)
+			transient = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+			err = decodeResponse(resp, nil)
+		}
+
+		//a token which could not be refreshed, i.e. because the grant was revoked, will not be refreshed by retrying
+		var refreshError *TokenRefreshError
+		if errors.As(err, &refreshError) {
+			transient = false
+		}
+
+		var apiError *APIError
+		if errors.As(err, &apiError) {
+			result.Causes = apiError.Causes
+		} else {
+			result.Causes = nil
+		}
+
+		result.Err = err
+
+		if err == nil {
+			result.Status = BulkUpdated
+			break
+		}
+
+		if !transient || result.Attempts > updater.config.MaxRetries {
+			break
+		}
+
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			err = updater.wait(ctx, resourcePath, retryAfter)
+		} else {
+			err = sleep(ctx, retryAfter)
+		}
+
+		if err != nil {
+			result.Err = err
+			break
+		}
+
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+
+	result.Duration = time.Since(start)
+
+	return result
+}
+
+/*wait waits for the rate limiter, plus the given extra time the API asked for, and records it*/
+func (updater *BulkUpdater) wait(ctx context.Context, resourcePath string, extra time.Duration) error {
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	start := time.Now()
+
+	err := sleep(ctx, extra)
+	if err == nil {
+		err = updater.limiter.wait(ctx)
+	}
+
+	if waited := time.Since(start); waited > time.Millisecond {
+		if recorder, ok := updater.client.instrument().(RateLimitRecorder); ok {
+			recorder.RateLimitWaited(resourcePath, waited)
+		}
+	}
+
+	return err
+}
+
+/*parseRetryAfter returns the seconds within a Retry-After header, or the fallback when there is none*/
+func parseRetryAfter(header string, fallback time.Duration) time.Duration {
+
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return fallback
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+/*sleep waits for the given time, or until ctx is done*/
+func sleep(ctx context.Context, d time.Duration) error {
+
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+/*rateLimiter spaces the calls evenly, so no more than the given requests per second are performed*/
+type rateLimiter struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / requestsPerSecond)}
+}
+
+/*wait blocks until the next call can be performed. A nil rateLimiter never blocks.*/
+func (limiter *rateLimiter) wait(ctx context.Context) error {
+
+	if limiter == nil {
+		return nil
+	}
+
+	limiter.mutex.Lock()
+	now := time.Now()
+	at := limiter.next
+	if at.Before(now) {
+		at = now
+	}
+	limiter.next = at.Add(limiter.interval)
+	limiter.mutex.Unlock()
+
+	return sleep(ctx, at.Sub(now))
+}
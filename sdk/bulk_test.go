@@ -0,0 +1,258 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+/*rateLimitInstrumentation records the rate limit waits*/
+type rateLimitInstrumentation struct {
+	mutex  sync.Mutex
+	waited time.Duration
+}
+
+func (instrumentation *rateLimitInstrumentation) StartCall(method string, resourcePath string) sdk.CallRecorder {
+	return noopRecorder{}
+}
+
+func (instrumentation *rateLimitInstrumentation) RateLimitWaited(resourcePath string, wait time.Duration) {
+	instrumentation.mutex.Lock()
+	defer instrumentation.mutex.Unlock()
+	instrumentation.waited += wait
+}
+
+type noopRecorder struct{}
+
+func (noopRecorder) TokenRefreshed(time.Time, error) {}
+func (noopRecorder) End(*http.Response, error)       {}
+
+func newBulkServer(items int) (*sdktest.Server, []sdk.ItemPatch) {
+
+	server := sdktest.NewServer()
+	server.AddUser(sdktest.User{ID: testSellerID, Nickname: "TEST_SELLER"})
+
+	var patches []sdk.ItemPatch
+	for i := 1; i <= items; i++ {
+		id := fmt.Sprintf("MLA%d", i)
+		server.AddItem(sdktest.Object{"id": id, "seller_id": testSellerID, "price": 10})
+		patches = append(patches, sdk.ItemPatch{ItemID: id, Changes: map[string]interface{}{"price": 10 + i}})
+	}
+
+	return server, patches
+}
+
+func Test_Bulk_updater_applies_every_patch_and_reports_in_order(t *testing.T) {
+
+	server, patches := newBulkServer(20)
+	defer server.Close()
+
+	_, client := newSellerClientOf(t, server)
+
+	patches = append(patches, sdk.ItemPatch{ItemID: "MLA404", Changes: map[string]interface{}{"price": 1}})
+
+	report := sdk.NewBulkUpdater(client, sdk.BulkConfig{Concurrency: 5}).UpdateAll(context.Background(), patches)
+
+	for i, result := range report[:20] {
+		if result.ItemID != patches[i].ItemID || result.Status != sdk.BulkUpdated || result.Attempts != 1 {
+			log.Printf("Error: unexpected result %+v", result)
+			t.FailNow()
+		}
+	}
+
+	if item, _ := server.Item("MLA20"); item["price"] != float64(30) {
+		log.Printf("Error: item was not updated %v", item)
+		t.FailNow()
+	}
+
+	if failed := report.Failed(); len(failed) != 1 || failed[0].StatusCode != http.StatusNotFound || failed[0].Attempts != 1 {
+		log.Printf("Error: unexpected failures %+v", failed)
+		t.FailNow()
+	}
+}
+
+func Test_Bulk_updater_retries_transient_failures_only(t *testing.T) {
+
+	server, patches := newBulkServer(2)
+	defer server.Close()
+
+	_, client := newSellerClientOf(t, server)
+
+	server.Fail(sdktest.Failure{Method: http.MethodPut, Path: "/items/MLA1", StatusCode: http.StatusServiceUnavailable, Times: 2})
+	server.Fail(sdktest.Failure{Method: http.MethodPut, Path: "/items/MLA2", StatusCode: http.StatusBadRequest, Times: 1,
+		Body: "{\"message\":\"Validation error\",\"error\":\"validation_error\",\"cause\":[{\"code\":\"item.price.invalid\",\"message\":\"price is invalid\"}]}"})
+
+	report := sdk.NewBulkUpdater(client, sdk.BulkConfig{RetryBackoff: time.Millisecond}).UpdateAll(context.Background(), patches)
+
+	if report[0].Status != sdk.BulkUpdated || report[0].Attempts != 3 {
+		log.Printf("Error: transient failures should have been retried %+v", report[0])
+		t.FailNow()
+	}
+
+	if report[1].Status != sdk.BulkFailed || report[1].Attempts != 1 || len(report[1].Causes) != 1 || report[1].Causes[0].Code != "item.price.invalid" {
+		log.Printf("Error: validation errors should not be retried %+v", report[1])
+		t.FailNow()
+	}
+
+	var csv, jsonReport bytes.Buffer
+	report.WriteCSV(&csv)
+	report.WriteJSON(&jsonReport)
+
+	if !strings.HasPrefix(csv.String(), "item_id,status,status_code,attempts,duration_ms,error,causes\n") ||
+		!strings.Contains(csv.String(), "MLA2,failed,400,1,") || !strings.Contains(csv.String(), "item.price.invalid: price is invalid") {
+		log.Printf("Error: unexpected CSV report %s", csv.String())
+		t.FailNow()
+	}
+
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal(jsonReport.Bytes(), &decoded); err != nil || len(decoded) != 2 || decoded[1]["error"] == nil || decoded[0]["attempts"] != float64(3) {
+		log.Printf("Error: unexpected JSON report %s", jsonReport.String())
+		t.FailNow()
+	}
+}
+
+func Test_Bulk_updater_does_not_retry_revoked_grants_nor_invalid_patches(t *testing.T) {
+
+	server, patches := newBulkServer(1)
+	defer server.Close()
+
+	//tokens expiring within a minute are refreshed before every call, which fails once the grant is revoked
+	server.SetTokenTTL(time.Second)
+	_, client := newSellerClientOf(t, server)
+
+	server.Revoke(testSellerID)
+
+	patches = append(patches, sdk.ItemPatch{ItemID: "MLA2", Changes: map[string]interface{}{"price": make(chan int)}})
+
+	report := sdk.NewBulkUpdater(client, sdk.BulkConfig{RetryBackoff: time.Millisecond}).UpdateAll(context.Background(), patches)
+
+	var refreshError *sdk.TokenRefreshError
+	if report[0].Status != sdk.BulkFailed || report[0].Attempts != 1 || !errors.As(report[0].Err, &refreshError) {
+		log.Printf("Error: a revoked grant should not be retried %+v", report[0])
+		t.FailNow()
+	}
+
+	if report[1].Status != sdk.BulkFailed || report[1].Attempts != 0 || report[1].Err == nil || report[1].Duration <= 0 {
+		log.Printf("Error: a patch which cannot be encoded should fail with its duration %+v", report[1])
+		t.FailNow()
+	}
+}
+
+func Test_Bulk_updater_limits_the_rate_and_records_the_waits(t *testing.T) {
+
+	server, patches := newBulkServer(6)
+	defer server.Close()
+
+	instrumentation := &rateLimitInstrumentation{}
+
+	config := server.Config(server.NewCode(testSellerID))
+	config.Instrumentation = instrumentation
+	client, _ := sdk.NewClientManager(sdk.ManagerConfig{}).Client(config)
+
+	patchesChannel := make(chan sdk.ItemPatch)
+	go func() {
+		for _, patch := range patches {
+			patchesChannel <- patch
+		}
+		close(patchesChannel)
+	}()
+
+	start := time.Now()
+
+	updated := 0
+	for result := range sdk.NewBulkUpdater(client, sdk.BulkConfig{Concurrency: 6, RequestsPerSecond: 50}).Run(context.Background(), patchesChannel) {
+		if result.Status == sdk.BulkUpdated {
+			updated++
+		}
+	}
+
+	//Six calls at 50 per second are spaced by 20ms.
+	if elapsed := time.Since(start); updated != 6 || elapsed < 100*time.Millisecond {
+		log.Printf("Error: %d items were updated in %s", updated, elapsed)
+		t.FailNow()
+	}
+
+	if instrumentation.waited < 100*time.Millisecond {
+		log.Printf("Error: rate limit waits were not recorded, obtained %s", instrumentation.waited)
+		t.FailNow()
+	}
+}
+
+func Test_Bulk_updater_reports_pending_patches_when_cancelled(t *testing.T) {
+
+	server, patches := newBulkServer(3)
+	defer server.Close()
+
+	_, client := newSellerClientOf(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report := sdk.NewBulkUpdater(client, sdk.BulkConfig{}).UpdateAll(ctx, patches)
+
+	if len(report.Failed()) != 3 || report[0].Err != context.Canceled || report[0].Attempts != 0 {
+		log.Printf("Error: every patch should have been cancelled %+v", report)
+		t.FailNow()
+	}
+}
+
+func Test_Bulk_updater_stops_when_cancelled_even_if_its_results_are_not_read(t *testing.T) {
+
+	server, patches := newBulkServer(10)
+	defer server.Close()
+
+	_, client := newSellerClientOf(t, server)
+
+	patchesChannel := make(chan sdk.ItemPatch, len(patches))
+	for _, patch := range patches {
+		patchesChannel <- patch
+	}
+	close(patchesChannel)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results := sdk.NewBulkUpdater(client, sdk.BulkConfig{Concurrency: 2}).Run(ctx, patchesChannel)
+
+	<-results
+	cancel()
+
+	//the workers blocked sending their results give up, so the channel is closed once they are done
+	time.Sleep(100 * time.Millisecond)
+
+	select {
+	case result, ok := <-results:
+		if ok {
+			log.Printf("Error: a result was sent after being cancelled %+v", result)
+			t.FailNow()
+		}
+	case <-time.After(time.Second):
+		log.Printf("Error: the results channel was not closed")
+		t.FailNow()
+	}
+}
@@ -0,0 +1,389 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package cassette provides an http.RoundTripper which records the traffic of a Client to a fixture file,
+and replays it later so tests run deterministically and offline.
+
+Access tokens, refresh tokens, client secrets and codes are scrubbed from the URLs, form encoded bodies and
+OAuth token exchanges before being written, so cassettes can be committed.
+
+Recording:
+
+	recorder, err := cassette.New("testdata/items.json", cassette.Options{Mode: cassette.Record})
+	defer recorder.Save()
+
+	client, err := sdk.MeliClient(sdk.MeliConfig{
+		...
+		HTTPOptions: sdk.HTTPOptions{Transport: recorder},
+	})
+
+Replaying is the same, using cassette.Replay as Mode.
+*/
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync"
+)
+
+/*Mode tells whether a Recorder records or replays the interactions*/
+type Mode int
+
+const (
+	//Replay answers the requests from the cassette and fails when no interaction matches.
+	Replay Mode = iota
+	//Record sends the requests through the real transport and keeps the interactions until Save is called.
+	Record
+)
+
+/*Matching tells how a request is matched against the recorded interactions*/
+type Matching int
+
+const (
+	//Strict matches method, path, query params and body. Interactions are replayed at most once.
+	Strict Matching = iota
+	//Lenient matches method and path only. The last matching interaction is replayed again once every one was used.
+	Lenient
+)
+
+const redacted = "REDACTED"
+
+/*ErrNoInteraction is returned, wrapped, when no recorded interaction matches a request being replayed*/
+var ErrNoInteraction = errors.New("cassette: no interaction matches the request")
+
+/*tokenPath is the OAuth endpoint whose JSON bodies hold tokens*/
+const tokenPath = "/oauth/token"
+
+/*scrubbedKeys are the query params, form fields and token exchange JSON fields which are never written to a cassette*/
+var scrubbedKeys = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"client_secret": true,
+	"code":          true,
+}
+
+type Options struct {
+	Mode     Mode
+	Matching Matching
+	//Transport sends the requests while recording. http.DefaultTransport is used when nil.
+	Transport http.RoundTripper
+}
+
+/*Interaction is a request and the response it got*/
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string     `json:"method"`
+	Path   string     `json:"path"`
+	Query  url.Values `json:"query,omitempty"`
+	Body   string     `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+/*Recorder is an http.RoundTripper which either records or replays a cassette. It is safe for concurrent use.*/
+type Recorder struct {
+	path    string
+	options Options
+
+	mutex        sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+/*
+New returns a Recorder for the cassette at the given path.
+When replaying, the cassette is loaded right away, so an error is returned if it cannot be read.
+*/
+func New(path string, options Options) (*Recorder, error) {
+
+	recorder := &Recorder{path: path, options: options}
+
+	if options.Transport == nil {
+		recorder.options.Transport = http.DefaultTransport
+	}
+
+	if options.Mode == Replay {
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var file cassetteFile
+		if err := json.Unmarshal(content, &file); err != nil {
+			return nil, fmt.Errorf("cassette: %s is not valid: %s", path, err)
+		}
+
+		recorder.interactions = file.Interactions
+		recorder.used = make([]bool, len(file.Interactions))
+	}
+
+	return recorder, nil
+}
+
+/*Interactions returns the interactions recorded or loaded so far*/
+func (recorder *Recorder) Interactions() []Interaction {
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return append([]Interaction(nil), recorder.interactions...)
+}
+
+/*Save writes the recorded interactions to the cassette. It does nothing when replaying.*/
+func (recorder *Recorder) Save() error {
+
+	if recorder.options.Mode != Record {
+		return nil
+	}
+
+	recorder.mutex.Lock()
+	content, err := json.MarshalIndent(cassetteFile{Interactions: recorder.interactions}, "", "  ")
+	recorder.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(recorder.path, content, 0644)
+}
+
+func (recorder *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	request := newRequest(req, body)
+
+	if recorder.options.Mode == Record {
+		return recorder.record(req, request)
+	}
+
+	return recorder.replay(req, request)
+}
+
+func (recorder *Recorder) record(req *http.Request, request Request) (*http.Response, error) {
+
+	resp, err := recorder.options.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.interactions = append(recorder.interactions, Interaction{
+		Request: request,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       scrubBody(request.Path, body),
+		},
+	})
+
+	return resp, nil
+}
+
+func (recorder *Recorder) replay(req *http.Request, request Request) (*http.Response, error) {
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	last := -1
+
+	for i, interaction := range recorder.interactions {
+
+		if !recorder.matches(interaction.Request, request) {
+			continue
+		}
+
+		last = i
+
+		if !recorder.used[i] {
+			recorder.used[i] = true
+			return newResponse(req, interaction.Response), nil
+		}
+	}
+
+	if last >= 0 && recorder.options.Matching == Lenient {
+		return newResponse(req, recorder.interactions[last].Response), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, request.Method, request.Path)
+}
+
+func (recorder *Recorder) matches(recorded Request, request Request) bool {
+
+	if recorded.Method != request.Method || recorded.Path != request.Path {
+		return false
+	}
+
+	if recorder.options.Matching == Lenient {
+		return true
+	}
+
+	return recorded.Query.Encode() == request.Query.Encode() && sameBody(recorded.Body, request.Body)
+}
+
+func newRequest(req *http.Request, body []byte) Request {
+
+	query := req.URL.Query()
+	for key := range query {
+		if scrubbedKeys[key] {
+			query.Set(key, redacted)
+		}
+	}
+
+	if len(query) == 0 {
+		query = nil
+	}
+
+	return Request{Method: req.Method, Path: req.URL.Path, Query: query, Body: scrubBody(req.URL.Path, body)}
+}
+
+func newResponse(req *http.Request, recorded Response) *http.Response {
+
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
+
+/*readBody reads the body of the request and puts it back, so it can still be sent*/
+func readBody(req *http.Request) ([]byte, error) {
+
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+/*
+scrubBody redacts the tokens of an OAuth token exchange, which are top level JSON fields, and the sensitive fields
+of a form encoded body. Other JSON bodies are kept as they are, as the API uses those names for other purposes,
+i.e. the code of every item returned by a multiget or of the causes of an error.
+*/
+func scrubBody(path string, body []byte) string {
+
+	if len(body) == 0 {
+		return string(body)
+	}
+
+	var value interface{}
+	if json.Unmarshal(body, &value) == nil {
+
+		fields, ok := value.(map[string]interface{})
+		if !ok || path != tokenPath {
+			return string(body)
+		}
+
+		for key := range fields {
+			if scrubbedKeys[key] {
+				fields[key] = redacted
+			}
+		}
+
+		scrubbed, _ := json.Marshal(fields)
+		return string(scrubbed)
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return string(body)
+	}
+
+	scrubbed := false
+	for key := range form {
+		if scrubbedKeys[key] {
+			form.Set(key, redacted)
+			scrubbed = true
+		}
+	}
+
+	if !scrubbed {
+		return string(body)
+	}
+
+	return form.Encode()
+}
+
+/*sameBody compares JSON bodies regardless of formatting and field order, and any other body byte by byte*/
+func sameBody(recorded string, body string) bool {
+
+	if recorded == body {
+		return true
+	}
+
+	var recordedValue, value interface{}
+	if json.Unmarshal([]byte(recorded), &recordedValue) != nil || json.Unmarshal([]byte(body), &value) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(recordedValue, value)
+}
@@ -0,0 +1,209 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassette
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+const testSellerID = 214509008
+
+/*recordCassette records a session against a fake API and returns the path of the saved cassette*/
+func recordCassette(t *testing.T) (string, sdk.MeliConfig) {
+
+	server := sdktest.NewServer()
+	defer server.Close()
+
+	server.AddUser(sdktest.User{ID: testSellerID, Nickname: "TEST_SELLER"})
+	server.AddItem(sdktest.Object{"id": "MLA1", "seller_id": testSellerID, "title": "Item"})
+
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	recorder, err := New(path, Options{Mode: Record})
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	config := server.Config(server.NewCode(testSellerID))
+	config.HTTPOptions.Transport = recorder
+
+	client, err := sdk.NewClientManager(sdk.ManagerConfig{}).Client(config)
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	client.Get("/items/MLA1")
+	client.Put("/items/MLA1", "{\"price\":20}")
+
+	if err := recorder.Save(); err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	return path, config
+}
+
+func Test_Recorded_session_is_replayed_without_the_server(t *testing.T) {
+
+	path, config := recordCassette(t)
+
+	recorder, err := New(path, Options{Mode: Replay})
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	config.HTTPOptions.Transport = recorder
+
+	client, err := sdk.NewClientManager(sdk.ManagerConfig{}).Client(config)
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	resp, err := client.Get("/items/MLA1")
+	if err != nil || resp.StatusCode != http.StatusOK {
+		log.Printf("Error: item was not replayed %v", err)
+		t.FailNow()
+	}
+
+	var item map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&item)
+
+	if item["title"] != "Item" {
+		log.Printf("Error: unexpected item %v", item)
+		t.FailNow()
+	}
+
+	if resp, err := client.Put("/items/MLA1", "{ \"price\": 20 }"); err != nil || resp.StatusCode != http.StatusOK {
+		log.Printf("Error: update was not replayed %v", err)
+		t.FailNow()
+	}
+}
+
+func Test_Secrets_and_tokens_are_scrubbed(t *testing.T) {
+
+	path, config := recordCassette(t)
+
+	content, _ := ioutil.ReadFile(path)
+
+	if strings.Contains(string(content), config.Secret) || strings.Contains(string(content), config.UserCode) {
+		log.Printf("Error: the cassette contains the client secret or the code")
+		t.FailNow()
+	}
+
+	recorder, _ := New(path, Options{Mode: Replay})
+
+	for _, interaction := range recorder.Interactions() {
+
+		if token := interaction.Request.Query.Get("access_token"); token != "" && token != redacted {
+			log.Printf("Error: access token was not scrubbed from %s", interaction.Request.Path)
+			t.FailNow()
+		}
+
+		if strings.Contains(interaction.Response.Body, "token") && !strings.Contains(interaction.Response.Body, redacted) {
+			log.Printf("Error: tokens were not scrubbed from %s", interaction.Response.Body)
+			t.FailNow()
+		}
+	}
+}
+
+func Test_Codes_of_other_resources_are_kept_while_forms_are_scrubbed(t *testing.T) {
+
+	multiget := `[{"code":200,"body":{"id":"MLA1"}}]`
+	apiError := `{"message":"invalid","status":400,"cause":[{"code":"item.price.invalid","message":"invalid price"}]}`
+
+	for _, body := range []string{multiget, apiError} {
+		if scrubbed := scrubBody("/items", []byte(body)); scrubbed != body {
+			log.Printf("Error: %s was scrubbed as %s", body, scrubbed)
+			t.FailNow()
+		}
+	}
+
+	token := scrubBody("/oauth/token", []byte(`{"access_token":"APP_USR-1","refresh_token":"TG-1","user_id":1}`))
+	if strings.Contains(token, "APP_USR-1") || strings.Contains(token, "TG-1") || !strings.Contains(token, `"user_id":1`) {
+		log.Printf("Error: the token exchange was not scrubbed %s", token)
+		t.FailNow()
+	}
+
+	form := scrubBody("/oauth/token", []byte("grant_type=refresh_token&client_secret=SECRET&refresh_token=TG-1"))
+	if strings.Contains(form, "SECRET") || strings.Contains(form, "TG-1") || !strings.Contains(form, "grant_type=refresh_token") {
+		log.Printf("Error: the form was not scrubbed %s", form)
+		t.FailNow()
+	}
+}
+
+func Test_Strict_matching_fails_on_a_different_body_or_a_repeated_request(t *testing.T) {
+
+	path, config := recordCassette(t)
+
+	recorder, _ := New(path, Options{Mode: Replay, Matching: Strict})
+	config.HTTPOptions.Transport = recorder
+
+	client, _ := sdk.NewClientManager(sdk.ManagerConfig{}).Client(config)
+
+	if _, err := client.Put("/items/MLA1", "{\"price\":30}"); !errors.Is(err, ErrNoInteraction) {
+		log.Printf("Error: expected ErrNoInteraction obtained %v", err)
+		t.FailNow()
+	}
+
+	client.Get("/items/MLA1")
+
+	if _, err := client.Get("/items/MLA1"); !errors.Is(err, ErrNoInteraction) {
+		log.Printf("Error: the interaction should have been replayed only once, obtained %v", err)
+		t.FailNow()
+	}
+}
+
+func Test_Lenient_matching_ignores_the_body_and_reuses_interactions(t *testing.T) {
+
+	path, config := recordCassette(t)
+
+	recorder, _ := New(path, Options{Mode: Replay, Matching: Lenient})
+	config.HTTPOptions.Transport = recorder
+
+	client, _ := sdk.NewClientManager(sdk.ManagerConfig{}).Client(config)
+
+	if resp, err := client.Put("/items/MLA1", "{\"price\":30}"); err != nil || resp.StatusCode != http.StatusOK {
+		log.Printf("Error: update was not replayed %v", err)
+		t.FailNow()
+	}
+
+	for i := 0; i < 2; i++ {
+		if resp, err := client.Get("/items/MLA1?attributes=title"); err != nil || resp.StatusCode != http.StatusOK {
+			log.Printf("Error: item was not replayed %v", err)
+			t.FailNow()
+		}
+	}
+
+	if _, err := client.Get("/items/MLA2"); !errors.Is(err, ErrNoInteraction) {
+		log.Printf("Error: expected ErrNoInteraction obtained %v", err)
+		t.FailNow()
+	}
+}
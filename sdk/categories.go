@@ -0,0 +1,50 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import "net/url"
+
+/*CategoryAttribute is an attribute the items of a category can or have to be published with*/
+type CategoryAttribute struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	ValueType string                 `json:"value_type"`
+	Tags      map[string]interface{} `json:"tags"`
+	Values    []AttributeValue       `json:"values,omitempty"`
+}
+
+/*AttributeValue is one of the values an attribute can take*/
+type AttributeValue struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+/*Required tells whether the items of the category have to be published with the attribute*/
+func (attribute CategoryAttribute) Required() bool {
+	return attribute.Tags["required"] == true || attribute.Tags["catalog_required"] == true
+}
+
+/*GetCategoryAttributes returns the attributes of a category*/
+func (client *Client) GetCategoryAttributes(categoryID string) ([]CategoryAttribute, error) {
+
+	var attributes []CategoryAttribute
+	if err := client.getJSON("/categories/"+url.PathEscape(categoryID)+"/attributes", &attributes); err != nil {
+		return nil, err
+	}
+
+	return attributes, nil
+}
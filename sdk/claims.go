@@ -0,0 +1,309 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+/*ClaimStatus tells whether a claim is still open*/
+type ClaimStatus string
+
+const (
+	ClaimOpened ClaimStatus = "opened"
+	ClaimClosed ClaimStatus = "closed"
+)
+
+/*ClaimStage is the stage a claim is in: the buyer and the seller talk first, and MercadoLibre mediates on a dispute*/
+type ClaimStage string
+
+const (
+	ClaimStageClaim     ClaimStage = "claim"
+	ClaimStageDispute   ClaimStage = "dispute"
+	ClaimStageRecontact ClaimStage = "recontact"
+	ClaimStageStale     ClaimStage = "stale"
+	ClaimStageNone      ClaimStage = "none"
+)
+
+/*The roles of the players of a claim*/
+const (
+	ClaimComplainant = "complainant"
+	ClaimRespondent  = "respondent"
+	ClaimMediator    = "mediator"
+)
+
+/*Some of the actions a player of a claim can take*/
+const (
+	ClaimActionSendMessageToComplainant = "send_message_to_complainant"
+	ClaimActionSendMessageToMediator    = "send_message_to_mediator"
+	ClaimActionRefund                   = "refund"
+	ClaimActionAllowReturn              = "allow_return"
+	ClaimActionOpenDispute              = "open_dispute"
+)
+
+/*ReturnStatus is the status of the return of the items of a claim*/
+type ReturnStatus string
+
+const (
+	ReturnPending        ReturnStatus = "pending"
+	ReturnLabelGenerated ReturnStatus = "label_generated"
+	ReturnShipped        ReturnStatus = "shipped"
+	ReturnDelivered      ReturnStatus = "delivered"
+	ReturnNotDelivered   ReturnStatus = "not_delivered"
+	ReturnCancelled      ReturnStatus = "cancelled"
+	ReturnExpired        ReturnStatus = "expired"
+	ReturnClosed         ReturnStatus = "closed"
+)
+
+/*Claim is a dispute opened by a buyer on a purchase, i.e. because the item did not arrive*/
+type Claim struct {
+	ID          int64            `json:"id"`
+	ResourceID  int64            `json:"resource_id"`
+	Resource    string           `json:"resource"`
+	Status      ClaimStatus      `json:"status"`
+	Type        string           `json:"type"`
+	Stage       ClaimStage       `json:"stage"`
+	ReasonID    string           `json:"reason_id"`
+	SiteID      string           `json:"site_id"`
+	Players     []ClaimPlayer    `json:"players"`
+	Resolution  *ClaimResolution `json:"resolution,omitempty"`
+	DateCreated time.Time        `json:"date_created"`
+	LastUpdated time.Time        `json:"last_updated"`
+}
+
+/*ClaimPlayer is a party of a claim, along with the actions it can take*/
+type ClaimPlayer struct {
+	Role             string        `json:"role"`
+	Type             string        `json:"type"`
+	UserID           int64         `json:"user_id"`
+	AvailableActions []ClaimAction `json:"available_actions"`
+}
+
+/*ClaimAction is an action a player can take. Mandatory actions have to be taken before the due date.*/
+type ClaimAction struct {
+	Action    string     `json:"action"`
+	DueDate   *time.Time `json:"due_date,omitempty"`
+	Mandatory bool       `json:"mandatory"`
+}
+
+/*ClaimResolution is how a closed claim was solved*/
+type ClaimResolution struct {
+	Reason      string    `json:"reason"`
+	Benefited   []string  `json:"benefited"`
+	ClosedBy    string    `json:"closed_by"`
+	DateCreated time.Time `json:"date_created"`
+}
+
+/*ClaimMessage is a message sent between the players of a claim*/
+type ClaimMessage struct {
+	SenderRole   string            `json:"sender_role"`
+	ReceiverRole string            `json:"receiver_role"`
+	Message      string            `json:"message"`
+	Attachments  []ClaimAttachment `json:"attachments"`
+	DateCreated  time.Time         `json:"date_created"`
+}
+
+/*ClaimAttachment is a file sent within a message or an evidence. Filename is the name it was uploaded with.*/
+type ClaimAttachment struct {
+	Filename         string    `json:"filename"`
+	OriginalFilename string    `json:"original_filename"`
+	Type             string    `json:"type"`
+	Size             int       `json:"size"`
+	DateCreated      time.Time `json:"date_created"`
+}
+
+/*ClaimEvidence is a proof given by a player, i.e. a shipping_evidence with the tracking of a shipment*/
+type ClaimEvidence struct {
+	Type        string            `json:"type"`
+	Attachments []ClaimAttachment `json:"attachments"`
+	DateCreated time.Time         `json:"date_created"`
+}
+
+/*Return is the shipment of the items of a claim back to the seller*/
+type Return struct {
+	ID           int64            `json:"id"`
+	ClaimID      int64            `json:"claim_id"`
+	Status       ReturnStatus     `json:"status"`
+	Subtype      string           `json:"subtype"`
+	ResourceType string           `json:"resource_type"`
+	Shipments    []ReturnShipment `json:"shipments"`
+	DateCreated  time.Time        `json:"date_created"`
+	LastUpdated  time.Time        `json:"last_updated"`
+}
+
+type ReturnShipment struct {
+	ShipmentID     int64  `json:"shipment_id"`
+	Status         string `json:"status"`
+	Type           string `json:"type"`
+	TrackingNumber string `json:"tracking_number"`
+}
+
+/*ClaimSearch filters the claims returned by SearchClaims. Zero values are not filtered on.*/
+type ClaimSearch struct {
+	Status ClaimStatus
+	Stage  ClaimStage
+	Type   string
+	//ResourceID is the id of the order, or of the shipment, the claim was opened on.
+	ResourceID int64
+	Offset     int
+	Limit      int
+}
+
+/*Player returns the player of the claim who is the given user, and whether there is one*/
+func (claim Claim) Player(userID int64) (ClaimPlayer, bool) {
+
+	for _, player := range claim.Players {
+		if player.UserID == userID {
+			return player, true
+		}
+	}
+
+	return ClaimPlayer{}, false
+}
+
+/*AvailableActions returns the actions the given user can take on the claim*/
+func (claim Claim) AvailableActions(userID int64) []ClaimAction {
+
+	player, _ := claim.Player(userID)
+	return player.AvailableActions
+}
+
+/*CanTake tells whether the given user can take the action on the claim*/
+func (claim Claim) CanTake(userID int64, action string) bool {
+
+	for _, available := range claim.AvailableActions(userID) {
+		if available.Action == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*SearchClaims returns the claims the user the client acts on behalf of is a player of*/
+func (client *Client) SearchClaims(search ClaimSearch) ([]Claim, Paging, error) {
+
+	query := url.Values{}
+	if search.Status != "" {
+		query.Set("status", string(search.Status))
+	}
+	if search.Stage != "" {
+		query.Set("stage", string(search.Stage))
+	}
+	if search.Type != "" {
+		query.Set("type", search.Type)
+	}
+	if search.ResourceID != 0 {
+		query.Set("resource_id", strconv.FormatInt(search.ResourceID, 10))
+	}
+	if search.Offset > 0 {
+		query.Set("offset", strconv.Itoa(search.Offset))
+	}
+	if search.Limit > 0 {
+		query.Set("limit", strconv.Itoa(search.Limit))
+	}
+
+	var result struct {
+		Data   []Claim `json:"data"`
+		Paging Paging  `json:"paging"`
+	}
+
+	if err := client.getJSON("/post-purchase/v1/claims/search?"+query.Encode(), &result); err != nil {
+		return nil, Paging{}, err
+	}
+
+	return result.Data, result.Paging, nil
+}
+
+/*GetClaim returns the claim with the given id*/
+func (client *Client) GetClaim(claimID int64) (*Claim, error) {
+
+	claim := new(Claim)
+	if err := client.getJSON(claimPath(claimID), claim); err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+/*GetClaimMessages returns the messages sent between the players of a claim*/
+func (client *Client) GetClaimMessages(claimID int64) ([]ClaimMessage, error) {
+
+	var messages []ClaimMessage
+	if err := client.getJSON(claimPath(claimID)+"/messages", &messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+/*
+SendClaimMessage sends a message to another player of a claim, i.e. ClaimComplainant.
+Attachments are the filenames returned by UploadClaimAttachment.
+*/
+func (client *Client) SendClaimMessage(claimID int64, receiverRole string, message string, attachments ...string) error {
+
+	if attachments == nil {
+		attachments = []string{}
+	}
+
+	body := map[string]interface{}{"receiver_role": receiverRole, "message": message, "attachments": attachments}
+
+	return client.postJSON(claimPath(claimID)+"/actions/send-message", body, nil)
+}
+
+/*UploadClaimAttachment uploads a file to a claim, and returns the filename to send it with SendClaimMessage*/
+func (client *Client) UploadClaimAttachment(claimID int64, file File) (string, error) {
+
+	var result struct {
+		Filename string `json:"filename"`
+	}
+
+	if err := client.postMultipart(claimPath(claimID)+"/attachments", nil, []File{file}, &result); err != nil {
+		return "", err
+	}
+
+	return result.Filename, nil
+}
+
+/*GetClaimEvidences returns the evidences given by the players of a claim*/
+func (client *Client) GetClaimEvidences(claimID int64) ([]ClaimEvidence, error) {
+
+	var evidences []ClaimEvidence
+	if err := client.getJSON(claimPath(claimID)+"/evidences", &evidences); err != nil {
+		return nil, err
+	}
+
+	return evidences, nil
+}
+
+/*GetClaimReturn returns the return of the items of a claim*/
+func (client *Client) GetClaimReturn(claimID int64) (*Return, error) {
+
+	claimReturn := new(Return)
+	if err := client.getJSON("/post-purchase/v2/claims/"+strconv.FormatInt(claimID, 10)+"/returns", claimReturn); err != nil {
+		return nil, err
+	}
+
+	return claimReturn, nil
+}
+
+func claimPath(claimID int64) string {
+	return "/post-purchase/v1/claims/" + strconv.FormatInt(claimID, 10)
+}
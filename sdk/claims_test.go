@@ -0,0 +1,138 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+func addClaim(server *sdktest.Server, id int64, orderID int64, stage string) {
+	server.AddClaim(sdktest.Object{"id": id, "resource_id": orderID, "resource": "order", "type": "mediations", "stage": stage,
+		"players": []sdktest.Object{
+			{"role": "complainant", "type": "buyer", "user_id": 1},
+			{"role": "respondent", "type": "seller", "user_id": testSellerID, "available_actions": []sdktest.Object{
+				{"action": "send_message_to_complainant", "mandatory": true, "due_date": "2026-10-20T10:00:00.000-04:00"},
+				{"action": "refund", "mandatory": false},
+			}},
+		}})
+}
+
+func Test_Claims_are_searched_and_read_with_their_actions(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	addClaim(server, 1, 100, "claim")
+	addClaim(server, 2, 200, "dispute")
+	server.AddClaim(sdktest.Object{"id": 3, "players": []sdktest.Object{{"role": "respondent", "user_id": 99}}})
+
+	claims, paging, err := client.SearchClaims(sdk.ClaimSearch{Status: sdk.ClaimOpened, Stage: sdk.ClaimStageDispute})
+
+	if err != nil || len(claims) != 1 || paging.Total != 1 || claims[0].ID != 2 || claims[0].ResourceID != 200 {
+		log.Printf("Error: unexpected claims %+v %+v %v", claims, paging, err)
+		t.FailNow()
+	}
+
+	claim, err := client.GetClaim(1)
+
+	if err != nil || claim.Status != sdk.ClaimOpened || claim.Stage != sdk.ClaimStageClaim || len(claim.Players) != 2 {
+		log.Printf("Error: unexpected claim %+v %v", claim, err)
+		t.FailNow()
+	}
+
+	actions := claim.AvailableActions(testSellerID)
+
+	if len(actions) != 2 || !actions[0].Mandatory || actions[0].DueDate == nil || !claim.CanTake(testSellerID, sdk.ClaimActionRefund) ||
+		claim.CanTake(testSellerID, sdk.ClaimActionOpenDispute) || claim.CanTake(1, sdk.ClaimActionRefund) {
+		log.Printf("Error: unexpected actions %+v", actions)
+		t.FailNow()
+	}
+
+	if _, err := client.GetClaim(3); err == nil {
+		log.Printf("Error: a claim of another seller was read")
+		t.FailNow()
+	}
+}
+
+func Test_Claim_messages_are_sent_with_uploaded_attachments(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	addClaim(server, 1, 100, "claim")
+
+	filename, err := client.UploadClaimAttachment(1, sdk.File{Name: "tracking.pdf", Content: strings.NewReader("%PDF-1.4")})
+	if err != nil || filename == "" {
+		log.Printf("Error: attachment was not uploaded %v", err)
+		t.FailNow()
+	}
+
+	attachment, ok := server.ClaimAttachments(1)[filename]
+	if !ok || attachment.Name != "tracking.pdf" || attachment.ContentType != "application/pdf" || string(attachment.Content) != "%PDF-1.4" {
+		log.Printf("Error: unexpected attachment %+v", attachment)
+		t.FailNow()
+	}
+
+	if err := client.SendClaimMessage(1, sdk.ClaimComplainant, "The item was shipped", filename); err != nil {
+		log.Printf("Error: message was not sent %v", err)
+		t.FailNow()
+	}
+
+	messages, err := client.GetClaimMessages(1)
+
+	if err != nil || len(messages) != 1 || messages[0].SenderRole != sdk.ClaimRespondent || messages[0].Message != "The item was shipped" ||
+		len(messages[0].Attachments) != 1 || messages[0].Attachments[0].OriginalFilename != "tracking.pdf" {
+		log.Printf("Error: unexpected messages %+v %v", messages, err)
+		t.FailNow()
+	}
+
+	if err := client.SendClaimMessage(1, sdk.ClaimComplainant, "Missing", "unknown.pdf"); err == nil {
+		log.Printf("Error: a message with an attachment which was not uploaded was sent")
+		t.FailNow()
+	}
+}
+
+func Test_Claim_evidences_and_return_are_read(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	addClaim(server, 1, 100, "claim")
+	server.AddClaimEvidence(1, sdktest.Object{"type": "shipping_evidence", "attachments": []sdktest.Object{{"filename": "label.pdf"}}})
+	server.SetClaimReturn(1, sdktest.Object{"id": 7, "status": "shipped", "subtype": "return_partial",
+		"shipments": []sdktest.Object{{"shipment_id": 55, "status": "shipped", "tracking_number": "TRK1"}}})
+
+	evidences, err := client.GetClaimEvidences(1)
+
+	if err != nil || len(evidences) != 1 || evidences[0].Type != "shipping_evidence" || evidences[0].Attachments[0].Filename != "label.pdf" {
+		log.Printf("Error: unexpected evidences %+v %v", evidences, err)
+		t.FailNow()
+	}
+
+	claimReturn, err := client.GetClaimReturn(1)
+
+	if err != nil || claimReturn.ClaimID != 1 || claimReturn.Status != sdk.ReturnShipped || len(claimReturn.Shipments) != 1 ||
+		claimReturn.Shipments[0].TrackingNumber != "TRK1" {
+		log.Printf("Error: unexpected return %+v %v", claimReturn, err)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"sync"
+	"time"
+)
+
+/*ErrMissingCurrency is returned when converting an amount from or to an empty currency id*/
+var ErrMissingCurrency = errors.New("money: the currency is required")
+
+/*Currency is a currency known by MercadoLibre*/
+type Currency struct {
+	ID            string `json:"id"`
+	Symbol        string `json:"symbol"`
+	Description   string `json:"description"`
+	DecimalPlaces int    `json:"decimal_places"`
+}
+
+/*CurrencyConversion is the rate to convert an amount of one currency to another: from * Ratio = to*/
+type CurrencyConversion struct {
+	CurrencyBase  string     `json:"currency_base"`
+	CurrencyQuote string     `json:"currency_quote"`
+	Ratio         float64    `json:"ratio"`
+	Rate          float64    `json:"rate"`
+	InvRate       float64    `json:"inv_rate"`
+	CreationDate  *time.Time `json:"creation_date,omitempty"`
+	ValidUntil    *time.Time `json:"valid_until,omitempty"`
+}
+
+/*
+decimalPlaces are the decimal places of the currencies whose amounts are not expressed in cents.
+RegisterCurrencies updates them with the ones returned by the API.
+*/
+var decimalPlaces = struct {
+	mutex  sync.RWMutex
+	places map[string]int
+}{places: map[string]int{"CLP": 0, "PYG": 0}}
+
+/*DecimalPlaces returns how many decimal places the amounts of the given currency have, which is 2 unless registered otherwise*/
+func DecimalPlaces(currencyID string) int {
+
+	decimalPlaces.mutex.RLock()
+	defer decimalPlaces.mutex.RUnlock()
+
+	if places, ok := decimalPlaces.places[currencyID]; ok {
+		return places
+	}
+
+	return 2
+}
+
+/*RegisterCurrencies sets the decimal places of the given currencies, i.e. the ones returned by GetCurrencies, so Money uses them*/
+func RegisterCurrencies(currencies ...Currency) {
+
+	decimalPlaces.mutex.Lock()
+	defer decimalPlaces.mutex.Unlock()
+
+	for _, currency := range currencies {
+		decimalPlaces.places[currency.ID] = currency.DecimalPlaces
+	}
+}
+
+/*GetCurrencies returns every currency known by MercadoLibre*/
+func (client *Client) GetCurrencies() ([]Currency, error) {
+
+	var currencies []Currency
+	if err := client.getJSON("/currencies", &currencies); err != nil {
+		return nil, err
+	}
+
+	return currencies, nil
+}
+
+/*GetCurrency returns the currency with the given id, i.e. "ARS"*/
+func (client *Client) GetCurrency(currencyID string) (*Currency, error) {
+
+	currency := new(Currency)
+	if err := client.getJSON("/currencies/"+url.PathEscape(currencyID), currency); err != nil {
+		return nil, err
+	}
+
+	return currency, nil
+}
+
+/*GetCurrencyConversion returns the current rate to convert amounts from one currency to another*/
+func (client *Client) GetCurrencyConversion(from string, to string) (*CurrencyConversion, error) {
+
+	query := url.Values{}
+	query.Set("from", from)
+	query.Set("to", to)
+
+	conversion := new(CurrencyConversion)
+	if err := client.getJSON("/currency_conversions/search?"+query.Encode(), conversion); err != nil {
+		return nil, err
+	}
+
+	return conversion, nil
+}
+
+/*DefaultConversionMaxAge is how long a CurrencyConverter uses a rate before fetching it again, unless told otherwise*/
+const DefaultConversionMaxAge = time.Hour
+
+/*
+CurrencyConverter converts amounts between currencies, keeping the rates it fetched for a while so converting
+many amounts takes a single call per pair of currencies. It is safe for concurrent use.
+*/
+type CurrencyConverter struct {
+	client *Client
+	maxAge time.Duration
+	now    func() time.Time
+
+	mutex sync.Mutex
+	rates map[string]cachedRate
+}
+
+type cachedRate struct {
+	ratio     float64
+	fetchedAt time.Time
+}
+
+/*NewCurrencyConverter returns a CurrencyConverter which fetches the rates through the client and uses them up to maxAge*/
+func NewCurrencyConverter(client *Client, maxAge time.Duration) *CurrencyConverter {
+
+	if maxAge <= 0 {
+		maxAge = DefaultConversionMaxAge
+	}
+
+	return &CurrencyConverter{client: client, maxAge: maxAge, now: time.Now, rates: make(map[string]cachedRate)}
+}
+
+/*Rate returns the ratio to convert amounts from one currency to another, fetching it if it is unknown or stale*/
+func (converter *CurrencyConverter) Rate(from string, to string) (float64, error) {
+
+	if from == "" || to == "" {
+		return 0, ErrMissingCurrency
+	}
+
+	if from == to {
+		return 1, nil
+	}
+
+	key := from + "/" + to
+
+	converter.mutex.Lock()
+	rate, ok := converter.rates[key]
+	converter.mutex.Unlock()
+
+	if ok && converter.now().Sub(rate.fetchedAt) < converter.maxAge {
+		return rate.ratio, nil
+	}
+
+	conversion, err := converter.client.GetCurrencyConversion(from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	converter.mutex.Lock()
+	converter.rates[key] = cachedRate{ratio: conversion.Ratio, fetchedAt: converter.now()}
+	converter.mutex.Unlock()
+
+	return conversion.Ratio, nil
+}
+
+/*
+Convert returns the amount in another currency, rounded to its decimal places half away from zero.
+ErrMissingCurrency is returned if the amount has no currency.
+*/
+func (converter *CurrencyConverter) Convert(money Money, to string) (Money, error) {
+
+	ratio, err := converter.Rate(money.CurrencyID, to)
+	if err != nil {
+		return Money{}, err
+	}
+
+	decimals := DecimalPlaces(to)
+	units := math.Round(float64(money.Units) * ratio * math.Pow10(decimals-money.Decimals))
+
+	return Money{Units: int64(units), Decimals: decimals, CurrencyID: to}, nil
+}
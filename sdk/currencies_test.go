@@ -0,0 +1,115 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"errors"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+)
+
+func Test_Currencies_are_read(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	currencies, err := client.GetCurrencies()
+	if err != nil || len(currencies) == 0 {
+		log.Printf("Error: unexpected currencies %+v %v", currencies, err)
+		t.FailNow()
+	}
+
+	currency, err := client.GetCurrency("CLP")
+	if err != nil || currency.DecimalPlaces != 0 || currency.Symbol != "$" {
+		log.Printf("Error: unexpected currency %+v %v", currency, err)
+		t.FailNow()
+	}
+
+	server.SetConversionRate("USD", "ARS", 950.5)
+
+	conversion, err := client.GetCurrencyConversion("USD", "ARS")
+	if err != nil || conversion.Ratio != 950.5 || conversion.CurrencyBase != "USD" || conversion.ValidUntil == nil {
+		log.Printf("Error: unexpected conversion %+v %v", conversion, err)
+		t.FailNow()
+	}
+}
+
+func Test_Converter_caches_rates_until_they_are_stale(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.SetConversionRate("USD", "ARS", 950.5)
+
+	now := time.Now()
+	converter := sdk.NewCurrencyConverter(client, time.Hour)
+	sdk.SetConverterClock(converter, func() time.Time { return now })
+	server.ResetRequests()
+
+	for i := 0; i < 3; i++ {
+		converted, err := converter.Convert(sdk.NewMoney(10.01, "USD"), "ARS")
+		if err != nil || converted.String() != "ARS 9514.51" {
+			log.Printf("Error: unexpected conversion %s %v", converted, err)
+			t.FailNow()
+		}
+	}
+
+	if same, _ := converter.Convert(sdk.NewMoney(5, "ARS"), "ARS"); same.Units != 500 || len(server.Requests()) != 1 {
+		log.Printf("Error: expected a single call, obtained %d", len(server.Requests()))
+		t.FailNow()
+	}
+
+	server.SetConversionRate("USD", "ARS", 1000)
+	now = now.Add(time.Hour)
+
+	if converted, _ := converter.Convert(sdk.NewMoney(1, "USD"), "ARS"); converted.Units != 100000 || len(server.Requests()) != 2 {
+		log.Printf("Error: the stale rate was not fetched again %s", converted)
+		t.FailNow()
+	}
+
+	if _, err := converter.Convert(sdk.NewMoney(1, "EUR"), "ARS"); err == nil {
+		log.Printf("Error: expected an error for an unknown conversion")
+		t.FailNow()
+	}
+}
+
+func Test_Converter_rounds_to_the_decimal_places_of_the_currency_and_requires_currencies(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.SetConversionRate("USD", "CLP", 912.347)
+	converter := sdk.NewCurrencyConverter(client, 0)
+
+	if converted, err := converter.Convert(sdk.NewMoney(10.01, "USD"), "CLP"); err != nil || converted.String() != "CLP 9133" {
+		log.Printf("Error: unexpected conversion %s %v", converted, err)
+		t.FailNow()
+	}
+
+	if _, err := converter.Convert(sdk.Money{Units: 100}, "ARS"); !errors.Is(err, sdk.ErrMissingCurrency) {
+		log.Printf("Error: expected ErrMissingCurrency obtained %v", err)
+		t.FailNow()
+	}
+
+	if _, err := converter.Convert(sdk.NewMoney(1, "USD"), ""); !errors.Is(err, sdk.ErrMissingCurrency) {
+		log.Printf("Error: expected ErrMissingCurrency obtained %v", err)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+	"unicode/utf8"
+)
+
+/*MaxDescriptionLength is the maximum number of characters of a plain text description*/
+const MaxDescriptionLength = 50000
+
+var (
+	//ErrDescriptionTooLong is returned, wrapped, when a description exceeds MaxDescriptionLength.
+	ErrDescriptionTooLong = errors.New("description is too long")
+	//ErrDescriptionHTML is returned, wrapped, when a description holds HTML, which the API does not accept anymore.
+	ErrDescriptionHTML = errors.New("description must be plain text")
+)
+
+var htmlTag = regexp.MustCompile(`</?[a-zA-Z][a-zA-Z0-9]*(\s[^<>]*)?/?>|<!--`)
+
+/*Description is the plain text description of an item*/
+type Description struct {
+	PlainText   string    `json:"plain_text"`
+	DateCreated time.Time `json:"date_created"`
+	LastUpdated time.Time `json:"last_updated"`
+}
+
+/*ValidateDescription checks a plain text description can be sent to the API*/
+func ValidateDescription(text string) error {
+
+	if length := utf8.RuneCountInString(text); length > MaxDescriptionLength {
+		return fmt.Errorf("%w: %d characters, up to %d are allowed", ErrDescriptionTooLong, length, MaxDescriptionLength)
+	}
+
+	if tag := htmlTag.FindString(text); tag != "" {
+		return fmt.Errorf("%w: %s was found", ErrDescriptionHTML, tag)
+	}
+
+	return nil
+}
+
+func descriptionPath(itemID string) string {
+	return "/items/" + url.PathEscape(itemID) + "/description"
+}
+
+/*GetDescription returns the description of an item*/
+func (client *Client) GetDescription(itemID string) (*Description, error) {
+
+	description := new(Description)
+	if err := client.getJSON(descriptionPath(itemID), description); err != nil {
+		return nil, err
+	}
+
+	return description, nil
+}
+
+/*CreateDescription sets the description of an item which has none. Use UpdateDescription once it was created.*/
+func (client *Client) CreateDescription(itemID string, text string) (*Description, error) {
+
+	if err := ValidateDescription(text); err != nil {
+		return nil, err
+	}
+
+	description := new(Description)
+	if err := client.postJSON(descriptionPath(itemID), Description{PlainText: text}.request(), description); err != nil {
+		return nil, err
+	}
+
+	return description, nil
+}
+
+/*UpdateDescription replaces the description of an item, which has to be created before with CreateDescription*/
+func (client *Client) UpdateDescription(itemID string, text string) (*Description, error) {
+
+	if err := ValidateDescription(text); err != nil {
+		return nil, err
+	}
+
+	description := new(Description)
+	if err := client.putJSON(descriptionPath(itemID)+"?api_version=2", Description{PlainText: text}.request(), description); err != nil {
+		return nil, err
+	}
+
+	return description, nil
+}
+
+/*request returns the body the API expects to create or update a description*/
+func (description Description) request() interface{} {
+	return struct {
+		PlainText string `json:"plain_text"`
+	}{description.PlainText}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+func Test_Descriptions_are_created_read_and_updated(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+	server.AddItem(sdktest.Object{"id": "MLA1", "seller_id": testSellerID, "title": "Item"})
+
+	if _, err := client.UpdateDescription("MLA1", "Not created yet"); err == nil {
+		log.Printf("Error: a missing description should not be updated")
+		t.FailNow()
+	}
+
+	if _, err := client.CreateDescription("MLA1", "Nuevo, en caja.\nIncluye garantía."); err != nil {
+		log.Printf("Error: description was not created %v", err)
+		t.FailNow()
+	}
+
+	_, err := client.CreateDescription("MLA1", "Again")
+
+	var apiError *sdk.APIError
+	if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusBadRequest {
+		log.Printf("Error: a description should be created once, obtained %v", err)
+		t.FailNow()
+	}
+
+	if _, err := client.UpdateDescription("MLA1", "Usado, sin caja."); err != nil {
+		log.Printf("Error: description was not updated %v", err)
+		t.FailNow()
+	}
+
+	description, err := client.GetDescription("MLA1")
+
+	if err != nil || description.PlainText != "Usado, sin caja." || description.LastUpdated.IsZero() {
+		log.Printf("Error: unexpected description %+v %v", description, err)
+		t.FailNow()
+	}
+}
+
+func Test_Descriptions_with_HTML_or_too_long_are_rejected(t *testing.T) {
+
+	valid := []string{"", "Talle < 40 y > 38", "Precio: 3<5 unidades", strings.Repeat("á", sdk.MaxDescriptionLength)}
+
+	for _, text := range valid {
+		if err := sdk.ValidateDescription(text); err != nil {
+			log.Printf("Error: a description of %d bytes should be valid, obtained %v", len(text), err)
+			t.FailNow()
+		}
+	}
+
+	invalid := map[string]error{
+		"<p>Nuevo</p>":      sdk.ErrDescriptionHTML,
+		"Nuevo<br/>en caja": sdk.ErrDescriptionHTML,
+		"<a href=\"http://example.com\">link</a>":       sdk.ErrDescriptionHTML,
+		"<!-- comment -->":                              sdk.ErrDescriptionHTML,
+		strings.Repeat("a", sdk.MaxDescriptionLength+1): sdk.ErrDescriptionTooLong,
+	}
+
+	for text, expected := range invalid {
+		if err := sdk.ValidateDescription(text); !errors.Is(err, expected) {
+			log.Printf("Error: expected %v obtained %v", expected, err)
+			t.FailNow()
+		}
+	}
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	if _, err := client.CreateDescription("MLA1", "<b>Nuevo</b>"); !errors.Is(err, sdk.ErrDescriptionHTML) {
+		log.Printf("Error: expected ErrDescriptionHTML obtained %v", err)
+		t.FailNow()
+	}
+
+	if len(server.Requests()) != 1 {
+		log.Printf("Error: an invalid description should not be sent, only the token request was expected")
+		t.FailNow()
+	}
+}
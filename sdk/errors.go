@@ -0,0 +1,97 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+/*
+APIError is returned by the typed methods when MercadoLibre API answers with a status code other than 2xx.
+The fields are filled in from the error body the API returns, when it can be parsed.
+*/
+type APIError struct {
+	StatusCode int          `json:"-"`
+	Message    string       `json:"message"`
+	ErrorCode  string       `json:"error"`
+	Causes     []ErrorCause `json:"-"`
+	Body       string       `json:"-"`
+}
+
+/*ErrorCause is one of the detailed reasons the API gives for rejecting a request*/
+type ErrorCause struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (err *APIError) Error() string {
+
+	if err.Message == "" {
+		return fmt.Sprintf("MercadoLibre API returned status code %d: %s", err.StatusCode, err.Body)
+	}
+
+	return fmt.Sprintf("MercadoLibre API returned status code %d: %s (%s)", err.StatusCode, err.Message, err.ErrorCode)
+}
+
+/*
+newAPIError builds an APIError from the given response, consuming its body.
+Causes can be either objects or plain strings, depending on the API.
+*/
+func newAPIError(resp *http.Response) *APIError {
+
+	var body []byte
+	if resp.Body != nil {
+		body, _ = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+
+	return apiErrorOf(resp.StatusCode, body)
+}
+
+/*apiErrorOf builds an APIError from the given status code and error body, i.e. the one of an item of a multiget*/
+func apiErrorOf(statusCode int, body []byte) *APIError {
+
+	apiError := &APIError{StatusCode: statusCode, Body: string(body)}
+
+	var payload struct {
+		Message   string            `json:"message"`
+		ErrorCode string            `json:"error"`
+		Causes    []json.RawMessage `json:"cause"`
+	}
+
+	if json.Unmarshal(body, &payload) != nil {
+		return apiError
+	}
+
+	apiError.Message = payload.Message
+	apiError.ErrorCode = payload.ErrorCode
+
+	for _, raw := range payload.Causes {
+		var cause ErrorCause
+		if json.Unmarshal(raw, &cause) != nil {
+			var message string
+			json.Unmarshal(raw, &message)
+			cause.Message = message
+		}
+		apiError.Causes = append(apiError.Causes, cause)
+	}
+
+	return apiError
+}
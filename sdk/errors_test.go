@@ -0,0 +1,54 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"testing"
+)
+
+func Test_APIError_is_built_from_the_error_body(t *testing.T) {
+
+	resp := &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Body: ioutil.NopCloser(bytes.NewReader([]byte(
+			"{\"message\":\"Validation error\",\"error\":\"validation_error\",\"status\":400," +
+				"\"cause\":[{\"code\":\"item.title.length.invalid\",\"message\":\"title is too long\"},\"plain cause\"]}"))),
+	}
+
+	err := decodeResponse(resp, nil)
+
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		log.Printf("Error: an APIError was expected, obtained %v", err)
+		t.FailNow()
+	}
+
+	if apiError.StatusCode != http.StatusBadRequest || apiError.ErrorCode != "validation_error" || len(apiError.Causes) != 2 {
+		log.Printf("Error: APIError was not properly parsed %+v", apiError)
+		t.FailNow()
+	}
+
+	if apiError.Causes[0].Code != "item.title.length.invalid" || apiError.Causes[1].Message != "plain cause" {
+		log.Printf("Error: causes were not properly parsed %+v", apiError.Causes)
+		t.FailNow()
+	}
+}
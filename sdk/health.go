@@ -0,0 +1,97 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import "net/url"
+
+/*ItemHealth is the quality score of an item, from 0 to 1, along with the goals it is computed from*/
+type ItemHealth struct {
+	ItemID string       `json:"item_id"`
+	Health float64      `json:"health"`
+	Level  string       `json:"level"`
+	Goals  []HealthGoal `json:"goals"`
+}
+
+/*HealthGoal is one of the aspects of an item its health is computed from, i.e. its pictures or attributes*/
+type HealthGoal struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Progress float64 `json:"progress"`
+	Apply    bool    `json:"apply"`
+}
+
+/*HealthAction is something the seller can do to improve the health of an item*/
+type HealthAction struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+/*AttributesQuality tells whether an item has every attribute its category requires*/
+type AttributesQuality struct {
+	ItemID            string   `json:"item_id"`
+	Complete          bool     `json:"complete"`
+	MissingAttributes []string `json:"missing_attributes"`
+}
+
+/*GetItemHealth returns the health of an item*/
+func (client *Client) GetItemHealth(itemID string) (*ItemHealth, error) {
+
+	health := new(ItemHealth)
+	if err := client.getJSON("/items/"+url.PathEscape(itemID)+"/health", health); err != nil {
+		return nil, err
+	}
+
+	return health, nil
+}
+
+/*GetItemHealthActions returns what can be done to improve the health of an item*/
+func (client *Client) GetItemHealthActions(itemID string) ([]HealthAction, error) {
+
+	var result struct {
+		Actions []HealthAction `json:"actions"`
+	}
+
+	if err := client.getJSON("/items/"+url.PathEscape(itemID)+"/health/actions", &result); err != nil {
+		return nil, err
+	}
+
+	return result.Actions, nil
+}
+
+/*GetAttributesQuality returns the attributes an item is missing for its category*/
+func (client *Client) GetAttributesQuality(itemID string) (*AttributesQuality, error) {
+
+	var result struct {
+		ItemID         string `json:"item_id"`
+		AdoptionStatus struct {
+			All struct {
+				Complete          bool     `json:"complete"`
+				MissingAttributes []string `json:"missing_attributes"`
+			} `json:"all"`
+		} `json:"adoption_status"`
+	}
+
+	if err := client.getJSON("/catalog_quality/status?v=3&item_id="+url.QueryEscape(itemID), &result); err != nil {
+		return nil, err
+	}
+
+	return &AttributesQuality{
+		ItemID:            result.ItemID,
+		Complete:          result.AdoptionStatus.All.Complete,
+		MissingAttributes: result.AdoptionStatus.All.MissingAttributes,
+	}, nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"log"
+	"testing"
+
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+func Test_Item_health_actions_and_missing_attributes_are_read(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.AddItem(sdktest.Object{"id": "MLA1", "seller_id": testSellerID, "category_id": "MLA1055",
+		"attributes": []sdktest.Object{{"id": "BRAND", "value_name": "Samsung"}}})
+
+	server.SetCategoryAttributes("MLA1055", []sdktest.Object{
+		{"id": "BRAND", "tags": sdktest.Object{"required": true}},
+		{"id": "MODEL", "tags": sdktest.Object{"catalog_required": true}},
+		{"id": "COLOR", "tags": sdktest.Object{}},
+	})
+
+	server.SetItemHealth("MLA1", sdktest.Object{"health": 0.6, "level": "basic", "goals": []sdktest.Object{
+		{"id": "pictures", "name": "Add pictures", "progress": 1, "apply": true},
+		{"id": "technical_specification", "name": "Complete the specifications", "progress": 0.5, "apply": true},
+	}})
+
+	health, err := client.GetItemHealth("MLA1")
+
+	if err != nil || health.Health != 0.6 || health.Level != "basic" || len(health.Goals) != 2 || health.Goals[1].Progress != 0.5 {
+		log.Printf("Error: unexpected health %+v %v", health, err)
+		t.FailNow()
+	}
+
+	actions, err := client.GetItemHealthActions("MLA1")
+
+	if err != nil || len(actions) != 1 || actions[0].ID != "technical_specification" {
+		log.Printf("Error: unexpected actions %+v %v", actions, err)
+		t.FailNow()
+	}
+
+	quality, err := client.GetAttributesQuality("MLA1")
+
+	if err != nil || quality.Complete || len(quality.MissingAttributes) != 1 || quality.MissingAttributes[0] != "MODEL" {
+		log.Printf("Error: unexpected attributes quality %+v %v", quality, err)
+		t.FailNow()
+	}
+}
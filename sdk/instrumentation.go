@@ -0,0 +1,93 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+)
+
+/*
+Instrumentation allows you to observe the API calls performed by a Client, i.e. to emit traces or metrics.
+StartCall is invoked before the access token is checked, so a token refresh always happens within a call.
+*/
+type Instrumentation interface {
+	StartCall(method string, resourcePath string) CallRecorder
+}
+
+/*
+CallRecorder follows a single API call.
+TokenRefreshed is called when the token had to be refreshed before executing the call, and End is always called once.
+resp is nil when err is not.
+*/
+type CallRecorder interface {
+	TokenRefreshed(start time.Time, err error)
+	End(resp *http.Response, err error)
+}
+
+/*
+RetryRecorder can be implemented by a CallRecorder which wants to know when its call retries failed ones,
+as the BulkUpdater does. Retried is called before any other method, with the number of failed attempts.
+*/
+type RetryRecorder interface {
+	Retried(retries int)
+}
+
+type noopInstrumentation struct{}
+
+func (noopInstrumentation) StartCall(string, string) CallRecorder { return noopCallRecorder{} }
+
+type noopCallRecorder struct{}
+
+func (noopCallRecorder) TokenRefreshed(time.Time, error) {}
+func (noopCallRecorder) End(*http.Response, error)       {}
+
+/*instrument returns the client instrumentation, falling back to one that does nothing.*/
+func (client *Client) instrument() Instrumentation {
+
+	if client.instrumentation == nil {
+		return noopInstrumentation{}
+	}
+
+	return client.instrumentation
+}
+
+/*
+ResourceTemplate returns a low cardinality version of the given resource path, suitable to be used
+as span name or metric label. The query string is removed and every path segment holding a digit,
+as item, user or order ids do, is replaced by {id}.
+
+i.e. /items/MLA123456/description?access_token=... returns /items/{id}/description
+*/
+func ResourceTemplate(resourcePath string) string {
+
+	if index := strings.IndexAny(resourcePath, "?#"); index >= 0 {
+		resourcePath = resourcePath[:index]
+	}
+
+	segments := strings.Split(resourcePath, "/")
+
+	for i, segment := range segments {
+		if strings.IndexFunc(segment, unicode.IsDigit) >= 0 {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,40 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"log"
+	"testing"
+)
+
+func Test_ResourceTemplate_replaces_ids_and_removes_the_query(t *testing.T) {
+
+	paths := map[string]string{
+		"/items/MLA123456/description?access_token=x": "/items/{id}/description",
+		"/users/me":                     "/users/me",
+		"/users/214509008/addresses":    "/users/{id}/addresses",
+		"/sites/MLA/listing_types":      "/sites/MLA/listing_types",
+		"/orders/search?seller=1234567": "/orders/search",
+	}
+
+	for path, expected := range paths {
+		if obtained := ResourceTemplate(path); obtained != expected {
+			log.Printf("Error: expected %s obtained %s", expected, obtained)
+			t.FailNow()
+		}
+	}
+}
@@ -0,0 +1,266 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*maxMultigetItems is how many items the /items?ids= API returns at once*/
+const maxMultigetItems = 20
+
+/*Item is a listing published by a seller*/
+type Item struct {
+	ID                string      `json:"id,omitempty"`
+	SiteID            string      `json:"site_id,omitempty"`
+	Title             string      `json:"title,omitempty"`
+	SellerID          int64       `json:"seller_id,omitempty"`
+	CategoryID        string      `json:"category_id,omitempty"`
+	Price             Money       `json:"price,omitempty"`
+	CurrencyID        string      `json:"currency_id,omitempty"`
+	AvailableQuantity int         `json:"available_quantity,omitempty"`
+	SoldQuantity      int         `json:"sold_quantity,omitempty"`
+	BuyingMode        string      `json:"buying_mode,omitempty"`
+	ListingTypeID     string      `json:"listing_type_id,omitempty"`
+	Condition         string      `json:"condition,omitempty"`
+	Status            string      `json:"status,omitempty"`
+	Permalink         string      `json:"permalink,omitempty"`
+	SellerCustomField string      `json:"seller_custom_field,omitempty"`
+	Attributes        []Attribute `json:"attributes,omitempty"`
+	Variations        []Variation `json:"variations,omitempty"`
+	Pictures          []Picture   `json:"pictures,omitempty"`
+	DateCreated       *time.Time  `json:"date_created,omitempty"`
+}
+
+/*MarshalJSON encodes the item as the API does, with its price as a plain number along with its currency_id*/
+func (item Item) MarshalJSON() ([]byte, error) {
+
+	type plain Item
+	encoded := struct {
+		plain
+		Price json.Number `json:"price,omitempty"`
+	}{plain: plain(item)}
+
+	if !item.Price.IsZero() {
+		encoded.Price = json.Number(item.Price.Amount())
+	}
+
+	if encoded.CurrencyID == "" {
+		encoded.CurrencyID = item.Price.CurrencyID
+	}
+
+	return json.Marshal(encoded)
+}
+
+/*UnmarshalJSON decodes the prices of the item and its variations exactly, in the decimal places of its currency*/
+func (item *Item) UnmarshalJSON(data []byte) error {
+
+	type plain Item
+	type plainVariation Variation
+	var decoded struct {
+		plain
+		Price      json.Number `json:"price"`
+		Variations []struct {
+			plainVariation
+			Price json.Number `json:"price"`
+		} `json:"variations"`
+	}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	price, err := decodeAmount(decoded.Price, decoded.CurrencyID)
+	if err != nil {
+		return err
+	}
+
+	*item = Item(decoded.plain)
+	item.Price = price
+	item.Variations = nil
+
+	for _, decodedVariation := range decoded.Variations {
+
+		variation := Variation(decodedVariation.plainVariation)
+		if variation.Price, err = decodeAmount(decodedVariation.Price, decoded.CurrencyID); err != nil {
+			return err
+		}
+
+		item.Variations = append(item.Variations, variation)
+	}
+
+	return nil
+}
+
+/*Picture is an image of an item. Source is the URL to upload it from when publishing.*/
+type Picture struct {
+	ID        string `json:"id,omitempty"`
+	Source    string `json:"source,omitempty"`
+	URL       string `json:"url,omitempty"`
+	SecureURL string `json:"secure_url,omitempty"`
+}
+
+/*Paging tells which page of the results of a search was returned*/
+type Paging struct {
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+/*GetItem returns the item with the given id*/
+func (client *Client) GetItem(itemID string) (*Item, error) {
+
+	item := new(Item)
+	if err := client.getJSON("/items/"+url.PathEscape(itemID), item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+/*
+GetItems returns the given items in the same order, using the multiget API, which reads up to 20 items at once.
+An *APIError is returned when any of them cannot be read, i.e. it does not exist.
+*/
+func (client *Client) GetItems(itemIDs []string) ([]Item, error) {
+
+	items := make([]Item, 0, len(itemIDs))
+
+	for start := 0; start < len(itemIDs); start += maxMultigetItems {
+
+		end := start + maxMultigetItems
+		if end > len(itemIDs) {
+			end = len(itemIDs)
+		}
+
+		var results []struct {
+			Code int             `json:"code"`
+			Body json.RawMessage `json:"body"`
+		}
+
+		if err := client.getJSON("/items?ids="+url.QueryEscape(strings.Join(itemIDs[start:end], ",")), &results); err != nil {
+			return nil, err
+		}
+
+		for _, result := range results {
+
+			if result.Code != http.StatusOK {
+				return nil, apiErrorOf(result.Code, result.Body)
+			}
+
+			var item Item
+			if err := json.Unmarshal(result.Body, &item); err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+/*CreateItem publishes an item and returns it as created. Use LintItem to find common rejection causes before.*/
+func (client *Client) CreateItem(item Item) (*Item, error) {
+
+	created := new(Item)
+	if err := client.postJSON("/items", item, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+/*
+UpdateItem applies the given changes to an item and returns it updated. changes is encoded as JSON,
+so a map or a struct with only the fields to be changed can be used.
+*/
+func (client *Client) UpdateItem(itemID string, changes interface{}) (*Item, error) {
+
+	item := new(Item)
+	if err := client.putJSON("/items/"+url.PathEscape(itemID), changes, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+/*
+SearchItemIDs returns a page of the ids of the items published by the user the client acts on behalf of, starting at offset.
+If status is not empty, only the items with that status are returned, i.e. "active" or "paused".
+*/
+func (client *Client) SearchItemIDs(status string, offset int) ([]string, Paging, error) {
+
+	return client.searchItemIDs(statusQuery("status", status), offset)
+}
+
+/*SearchAllItemIDs returns the ids of every item of the user with the given status, reading one page after the other*/
+func (client *Client) SearchAllItemIDs(status string) ([]string, error) {
+
+	return client.searchAllItemIDs(statusQuery("status", status))
+}
+
+func (client *Client) searchItemIDs(query url.Values, offset int) ([]string, Paging, error) {
+
+	var result struct {
+		Results []string `json:"results"`
+		Paging  Paging   `json:"paging"`
+	}
+
+	query.Set("offset", strconv.Itoa(offset))
+
+	resource := "/users/" + strconv.FormatInt(client.UserID(), 10) + "/items/search?" + query.Encode()
+	if err := client.getJSON(resource, &result); err != nil {
+		return nil, Paging{}, err
+	}
+
+	return result.Results, result.Paging, nil
+}
+
+func (client *Client) searchAllItemIDs(query url.Values) ([]string, error) {
+
+	var itemIDs []string
+
+	for {
+		page, paging, err := client.searchItemIDs(query, len(itemIDs))
+		if err != nil {
+			return nil, err
+		}
+
+		itemIDs = append(itemIDs, page...)
+
+		if len(page) == 0 || len(itemIDs) >= paging.Total {
+			return itemIDs, nil
+		}
+	}
+}
+
+/*statusQuery returns a query holding the given status param, or an empty one when status is empty*/
+func statusQuery(param string, status string) url.Values {
+
+	query := url.Values{}
+	if status != "" {
+		query.Set(param, status)
+	}
+
+	return query
+}
@@ -0,0 +1,126 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+const testSellerID = 214509008
+
+/*newSellerClient returns a fake API with a seller, and a client acting on its behalf*/
+func newSellerClient(t *testing.T) (*sdktest.Server, *sdk.Client) {
+
+	server := sdktest.NewServer()
+	server.AddUser(sdktest.User{ID: testSellerID, Nickname: "TEST_SELLER", SiteID: sdk.SiteMLA})
+
+	return newSellerClientOf(t, server)
+}
+
+/*newSellerClientOf returns a client acting on behalf of the seller of the given fake API*/
+func newSellerClientOf(t *testing.T, server *sdktest.Server) (*sdktest.Server, *sdk.Client) {
+
+	client, err := sdk.NewClientManager(sdk.ManagerConfig{}).Client(server.Config(server.NewCode(testSellerID)))
+
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	return server, client
+}
+
+func Test_Items_are_read_updated_and_searched(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.AddItem(sdktest.Object{"id": "MLA1", "seller_id": testSellerID, "title": "Item", "price": 10, "status": "active"})
+	server.AddItem(sdktest.Object{"id": "MLA2", "seller_id": testSellerID, "title": "Other", "status": "paused"})
+
+	item, err := client.GetItem("MLA1")
+
+	if err != nil || item.Title != "Item" || item.Price.Amount() != "10.00" || item.SellerID != testSellerID {
+		log.Printf("Error: unexpected item %+v %v", item, err)
+		t.FailNow()
+	}
+
+	item, err = client.UpdateItem("MLA1", map[string]interface{}{"price": 20})
+
+	if err != nil || item.Price.Amount() != "20.00" {
+		log.Printf("Error: item was not updated %+v %v", item, err)
+		t.FailNow()
+	}
+
+	ids, paging, err := client.SearchItemIDs("paused", 0)
+
+	if err != nil || len(ids) != 1 || ids[0] != "MLA2" || paging.Total != 1 {
+		log.Printf("Error: unexpected search results %v %+v %v", ids, paging, err)
+		t.FailNow()
+	}
+}
+
+func Test_Items_are_read_from_every_page_of_the_search_and_with_multigets(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	for i := 1; i <= 60; i++ {
+		server.AddItem(sdktest.Object{"id": fmt.Sprintf("MLA%03d", i), "seller_id": testSellerID, "title": "Item", "price": i, "currency_id": "ARS", "status": "active"})
+	}
+
+	ids, err := client.SearchAllItemIDs("active")
+
+	if err != nil || len(ids) != 60 {
+		log.Printf("Error: expected every item id obtained %d %v", len(ids), err)
+		t.FailNow()
+	}
+
+	items, err := client.GetItems(ids)
+
+	if err != nil || len(items) != 60 || items[0].ID != "MLA001" || items[59].ID != "MLA060" || items[59].Price.Amount() != "60.00" {
+		log.Printf("Error: unexpected items %d %v", len(items), err)
+		t.FailNow()
+	}
+
+	var apiError *sdk.APIError
+	if _, err := client.GetItems([]string{"MLA001", "MLA404"}); !errors.As(err, &apiError) || apiError.StatusCode != http.StatusNotFound {
+		log.Printf("Error: expected a not found APIError obtained %v", err)
+		t.FailNow()
+	}
+}
+
+func Test_Missing_item_returns_an_APIError(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	_, err := client.GetItem("MLA404")
+
+	var apiError *sdk.APIError
+	if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusNotFound {
+		log.Printf("Error: expected a not found APIError obtained %v", err)
+		t.FailNow()
+	}
+}
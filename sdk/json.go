@@ -0,0 +1,51 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import "encoding/json"
+
+/*postJSON performs a POST over the given resource with v encoded as JSON, and decodes the response into result*/
+func (client *Client) postJSON(resourcePath string, v interface{}, result interface{}) error {
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Post(resourcePath, string(body))
+	if err != nil {
+		return err
+	}
+
+	return decodeResponse(resp, result)
+}
+
+/*putJSON performs a PUT over the given resource with v encoded as JSON, and decodes the response into result*/
+func (client *Client) putJSON(resourcePath string, v interface{}, result interface{}) error {
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Put(resourcePath, string(body))
+	if err != nil {
+		return err
+	}
+
+	return decodeResponse(resp, result)
+}
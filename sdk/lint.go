@@ -0,0 +1,194 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+/*MaxTitleLength is the maximum number of characters of an item title within most categories*/
+const MaxTitleLength = 60
+
+/*LintIssue is a problem found within an item which would make the API reject it, or publish it with a poor quality*/
+type LintIssue struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+func (issue LintIssue) String() string {
+	return issue.Code + ": " + issue.Message
+}
+
+/*LintRules tells what LintItem checks beyond the fields every item needs*/
+type LintRules struct {
+	//MaxTitleLength defaults to sdk.MaxTitleLength.
+	MaxTitleLength int
+	//MinPictures defaults to one. Use -1 to accept items without pictures.
+	MinPictures int
+	//RequiredAttributes are the ids of the attributes the category requires.
+	RequiredAttributes []string
+}
+
+/*
+LintItem checks an item before publishing it, and returns the common rejection causes found:
+missing fields, title length, pictures and required attributes. No issues are returned for a valid item.
+*/
+func LintItem(item Item, rules LintRules) []LintIssue {
+
+	if rules.MaxTitleLength <= 0 {
+		rules.MaxTitleLength = MaxTitleLength
+	}
+
+	if rules.MinPictures == 0 {
+		rules.MinPictures = 1
+	}
+
+	var issues []LintIssue
+
+	add := func(field string, code string, message string, args ...interface{}) {
+		issues = append(issues, LintIssue{Field: field, Code: code, Message: fmt.Sprintf(message, args...)})
+	}
+
+	title := strings.TrimSpace(item.Title)
+
+	if title == "" {
+		add("title", "item.title.missing", "the title is required")
+	} else if length := utf8.RuneCountInString(title); length > rules.MaxTitleLength {
+		add("title", "item.title.length.invalid", "the title has %d characters, up to %d are allowed", length, rules.MaxTitleLength)
+	}
+
+	if item.CategoryID == "" {
+		add("category_id", "item.category_id.missing", "the category is required")
+	}
+
+	if item.Price.Units <= 0 {
+		add("price", "item.price.invalid", "the price has to be greater than zero")
+	}
+
+	//the currency is sent from the price when the item has none
+	if item.CurrencyID == "" && item.Price.CurrencyID == "" {
+		add("currency_id", "item.currency_id.missing", "the currency is required")
+	}
+
+	if item.BuyingMode == "" {
+		add("buying_mode", "item.buying_mode.missing", "the buying mode is required")
+	}
+
+	if item.ListingTypeID == "" {
+		add("listing_type_id", "item.listing_type_id.missing", "the listing type is required")
+	}
+
+	if item.Condition == "" {
+		add("condition", "item.condition.missing", "the condition is required")
+	}
+
+	if item.stock() <= 0 {
+		add("available_quantity", "item.available_quantity.invalid", "the available quantity has to be greater than zero")
+	}
+
+	if rules.MinPictures > 0 && len(item.Pictures) < rules.MinPictures {
+		add("pictures", "item.pictures.missing", "at least %d pictures are required, %d were given", rules.MinPictures, len(item.Pictures))
+	}
+
+	for i, variation := range item.Variations {
+		if len(variation.AttributeCombinations) == 0 {
+			add(fmt.Sprintf("variations[%d]", i), "item.variations.attribute_combinations.missing", "every variation needs the attributes it varies on, i.e. COLOR")
+		}
+	}
+
+	for _, id := range rules.RequiredAttributes {
+		if !item.hasAttribute(id) {
+			add("attributes", "item.attributes.missing_required", "the attribute %s is required by the category", id)
+		}
+	}
+
+	return issues
+}
+
+/*
+LintItem checks an item as sdk.LintItem does, taking the required attributes from its category.
+Call it before CreateItem.
+*/
+func (client *Client) LintItem(item Item) ([]LintIssue, error) {
+
+	rules := LintRules{}
+
+	if item.CategoryID != "" {
+
+		attributes, err := client.GetCategoryAttributes(item.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, attribute := range attributes {
+			if attribute.Required() {
+				rules.RequiredAttributes = append(rules.RequiredAttributes, attribute.ID)
+			}
+		}
+	}
+
+	return LintItem(item, rules), nil
+}
+
+/*stock returns the available quantity of the item, adding up its variations when it has any*/
+func (item Item) stock() int {
+
+	if len(item.Variations) == 0 {
+		return item.AvailableQuantity
+	}
+
+	stock := 0
+	for _, variation := range item.Variations {
+		stock += variation.AvailableQuantity
+	}
+
+	return stock
+}
+
+/*hasAttribute tells whether the item, or every one of its variations, has a value for the given attribute*/
+func (item Item) hasAttribute(id string) bool {
+
+	if hasValue(item.Attributes, id) {
+		return true
+	}
+
+	if len(item.Variations) == 0 {
+		return false
+	}
+
+	for _, variation := range item.Variations {
+		if !hasValue(variation.AttributeCombinations, id) && !hasValue(variation.Attributes, id) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hasValue(attributes []Attribute, id string) bool {
+
+	for _, attribute := range attributes {
+		if attribute.ID == id && (attribute.ValueID != "" || strings.TrimSpace(attribute.ValueName) != "") {
+			return true
+		}
+	}
+
+	return false
+}
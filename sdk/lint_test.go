@@ -0,0 +1,139 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+func validItem() sdk.Item {
+	return sdk.Item{
+		Title:             "Item de test - No Ofertar",
+		CategoryID:        "MLA1912",
+		Price:             sdk.NewMoney(10, "ARS"),
+		CurrencyID:        "ARS",
+		AvailableQuantity: 1,
+		BuyingMode:        "buy_it_now",
+		ListingTypeID:     "gold_special",
+		Condition:         "new",
+		Pictures:          []sdk.Picture{{Source: "http://upload.wikimedia.org/wikipedia/commons/f/fd/Ray_Ban_Original_Wayfarer.jpg"}},
+		Attributes:        []sdk.Attribute{{ID: "BRAND", ValueName: "Ray-Ban"}},
+	}
+}
+
+func codes(issues []sdk.LintIssue) string {
+
+	codes := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		codes = append(codes, issue.Code)
+	}
+
+	return strings.Join(codes, ",")
+}
+
+func Test_Valid_item_has_no_lint_issues(t *testing.T) {
+
+	if issues := sdk.LintItem(validItem(), sdk.LintRules{RequiredAttributes: []string{"BRAND"}}); len(issues) != 0 {
+		log.Printf("Error: unexpected issues %v", issues)
+		t.FailNow()
+	}
+
+	//the currency is sent from the price
+	item := validItem()
+	item.Price = sdk.NewMoney(100, "ARS")
+	item.CurrencyID = ""
+
+	if issues := sdk.LintItem(item, sdk.LintRules{}); len(issues) != 0 {
+		log.Printf("Error: the currency of the price should be accepted %v", issues)
+		t.FailNow()
+	}
+}
+
+func Test_Lint_flags_common_rejection_causes(t *testing.T) {
+
+	item := validItem()
+	item.Title = strings.Repeat("Anteojos ", 10)
+	item.Pictures = nil
+	item.Price = sdk.Money{}
+
+	issues := sdk.LintItem(item, sdk.LintRules{RequiredAttributes: []string{"BRAND", "MODEL"}})
+
+	if codes(issues) != "item.title.length.invalid,item.price.invalid,item.pictures.missing,item.attributes.missing_required" {
+		log.Printf("Error: unexpected issues %v", issues)
+		t.FailNow()
+	}
+
+	if issues[3].Field != "attributes" || !strings.Contains(issues[3].Message, "MODEL") {
+		log.Printf("Error: unexpected issue %+v", issues[3])
+		t.FailNow()
+	}
+
+	if issues := sdk.LintItem(sdk.Item{}, sdk.LintRules{MinPictures: -1}); len(issues) != 8 {
+		log.Printf("Error: every missing field should be flagged %v", issues)
+		t.FailNow()
+	}
+}
+
+func Test_Lint_checks_the_variations(t *testing.T) {
+
+	item := validItem()
+	item.AvailableQuantity = 0
+	item.Variations = []sdk.Variation{
+		{AvailableQuantity: 2, AttributeCombinations: []sdk.Attribute{{ID: "COLOR", ValueName: "Rojo"}}},
+		{AvailableQuantity: 0},
+	}
+
+	issues := sdk.LintItem(item, sdk.LintRules{RequiredAttributes: []string{"COLOR"}})
+
+	if codes(issues) != "item.variations.attribute_combinations.missing,item.attributes.missing_required" || issues[0].Field != "variations[1]" {
+		log.Printf("Error: unexpected issues %v", issues)
+		t.FailNow()
+	}
+}
+
+func Test_Client_lints_with_the_required_attributes_of_the_category(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.SetCategoryAttributes("MLA1912", []sdktest.Object{
+		{"id": "BRAND", "tags": sdktest.Object{"required": true}},
+		{"id": "MODEL", "tags": sdktest.Object{"required": true}},
+	})
+
+	issues, err := client.LintItem(validItem())
+
+	if err != nil || codes(issues) != "item.attributes.missing_required" {
+		log.Printf("Error: unexpected issues %v %v", issues, err)
+		t.FailNow()
+	}
+
+	item := validItem()
+	item.Attributes = append(item.Attributes, sdk.Attribute{ID: "MODEL", ValueName: "Wayfarer"})
+
+	created, err := client.CreateItem(item)
+
+	if err != nil || created.ID == "" || created.Title != item.Title || len(created.Pictures) != 1 {
+		log.Printf("Error: item was not created %+v %v", created, err)
+		t.FailNow()
+	}
+}
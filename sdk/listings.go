@@ -0,0 +1,213 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+)
+
+/*ErrListingTypeNotFound is returned by CalculateFees when the site has no prices for the listing type*/
+var ErrListingTypeNotFound = errors.New("listing type not found")
+
+/*ListingType is a way of publishing items within a site, i.e. gold_special or free, each with its own fees and exposure*/
+type ListingType struct {
+	SiteID string `json:"site_id"`
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+}
+
+/*ListingExposure tells where the items of a listing type are shown*/
+type ListingExposure struct {
+	ID                       string `json:"id"`
+	Name                     string `json:"name"`
+	HomePage                 bool   `json:"home_page"`
+	CategoryHomePage         bool   `json:"category_home_page"`
+	AdvertisingOnListingPage bool   `json:"advertising_on_listing_page"`
+	PriorityInSearch         int    `json:"priority_in_search"`
+}
+
+/*ListingPrice is what publishing and selling an item with a listing type costs*/
+type ListingPrice struct {
+	ListingTypeID    string         `json:"listing_type_id"`
+	ListingTypeName  string         `json:"listing_type_name"`
+	ListingExposure  string         `json:"listing_exposure"`
+	CurrencyID       string         `json:"currency_id"`
+	SaleFeeAmount    float64        `json:"sale_fee_amount"`
+	SaleFeeDetails   SaleFeeDetails `json:"sale_fee_details"`
+	ListingFeeAmount float64        `json:"listing_fee_amount"`
+	RequiresPicture  bool           `json:"requires_picture"`
+}
+
+/*SaleFeeDetails breaks the sale fee down. PercentageFee and MeliPercentageFee are percents, the rest are amounts.*/
+type SaleFeeDetails struct {
+	PercentageFee     float64 `json:"percentage_fee"`
+	MeliPercentageFee float64 `json:"meli_percentage_fee"`
+	FixedFee          float64 `json:"fixed_fee"`
+	GrossAmount       float64 `json:"gross_amount"`
+	FinancingAddOnFee float64 `json:"financing_add_on_fee"`
+}
+
+/*ListingPriceQuery tells what to get the listing prices for. CategoryID and ListingTypeID are optional.*/
+type ListingPriceQuery struct {
+	Price         float64
+	CategoryID    string
+	ListingTypeID string
+}
+
+/*
+FeeBreakdown is what MercadoLibre keeps from a sale. NetAmount is what the seller receives from each sale before shipping
+and taxes. ListingFee is charged once per listing instead of per sale, so it is not subtracted from NetAmount.
+Amounts are rounded to the decimal places of the currency.
+*/
+type FeeBreakdown struct {
+	Price               float64
+	CurrencyID          string
+	ListingTypeID       string
+	PercentageFee       float64
+	PercentageFeeAmount float64
+	FixedFee            float64
+	FinancingAddOnFee   float64
+	SaleFee             float64
+	ListingFee          float64
+	NetAmount           float64
+}
+
+/*GetListingTypes returns the listing types of a site*/
+func (client *Client) GetListingTypes(siteID string) ([]ListingType, error) {
+
+	var types []ListingType
+	if err := client.getJSON("/sites/"+url.PathEscape(siteID)+"/listing_types", &types); err != nil {
+		return nil, err
+	}
+
+	return types, nil
+}
+
+/*GetListingExposures returns the exposures of the listing types of a site*/
+func (client *Client) GetListingExposures(siteID string) ([]ListingExposure, error) {
+
+	var exposures []ListingExposure
+	if err := client.getJSON("/sites/"+url.PathEscape(siteID)+"/listing_exposures", &exposures); err != nil {
+		return nil, err
+	}
+
+	return exposures, nil
+}
+
+/*
+GetListingPrices returns the listing prices of a site for the given price, one per listing type, or only the one
+of query.ListingTypeID when it is set
+*/
+func (client *Client) GetListingPrices(siteID string, query ListingPriceQuery) ([]ListingPrice, error) {
+
+	params := url.Values{}
+	params.Set("price", strconv.FormatFloat(query.Price, 'f', -1, 64))
+
+	if query.CategoryID != "" {
+		params.Set("category_id", query.CategoryID)
+	}
+
+	if query.ListingTypeID != "" {
+		params.Set("listing_type_id", query.ListingTypeID)
+	}
+
+	//The API returns a single object when a listing type is requested, and an array otherwise.
+	var raw json.RawMessage
+	if err := client.getJSON("/sites/"+url.PathEscape(siteID)+"/listing_prices?"+params.Encode(), &raw); err != nil {
+		return nil, err
+	}
+
+	var prices []ListingPrice
+
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(raw, &prices); err != nil {
+			return nil, err
+		}
+		return prices, nil
+	}
+
+	var price ListingPrice
+	if err := json.Unmarshal(raw, &price); err != nil {
+		return nil, err
+	}
+
+	return append(prices, price), nil
+}
+
+/*
+CalculateFees returns what MercadoLibre keeps from selling an item of the given category, with the given
+price and listing type, so margins can be computed before publishing.
+ErrListingTypeNotFound is returned when the listing type is empty or the site does not price it.
+*/
+func (client *Client) CalculateFees(siteID string, price float64, categoryID string, listingTypeID string) (*FeeBreakdown, error) {
+
+	//without a listing type the API returns the prices of all of them, so none could be picked
+	if listingTypeID == "" {
+		return nil, fmt.Errorf("%w: the listing type is required", ErrListingTypeNotFound)
+	}
+
+	prices, err := client.GetListingPrices(siteID, ListingPriceQuery{Price: price, CategoryID: categoryID, ListingTypeID: listingTypeID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, listingPrice := range prices {
+		if listingPrice.ListingTypeID == listingTypeID {
+			return listingPrice.Fees(price), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s within site %s", ErrListingTypeNotFound, listingTypeID, siteID)
+}
+
+/*Fees breaks down the listing price for an item with the given price*/
+func (listingPrice ListingPrice) Fees(price float64) *FeeBreakdown {
+
+	details := listingPrice.SaleFeeDetails
+	decimals := DecimalPlaces(listingPrice.CurrencyID)
+
+	return &FeeBreakdown{
+		Price:               price,
+		CurrencyID:          listingPrice.CurrencyID,
+		ListingTypeID:       listingPrice.ListingTypeID,
+		PercentageFee:       details.PercentageFee,
+		PercentageFeeAmount: roundTo(price*details.PercentageFee/100, decimals),
+		FixedFee:            details.FixedFee,
+		FinancingAddOnFee:   details.FinancingAddOnFee,
+		SaleFee:             listingPrice.SaleFeeAmount,
+		ListingFee:          listingPrice.ListingFeeAmount,
+		NetAmount:           roundTo(price-listingPrice.SaleFeeAmount, decimals),
+	}
+}
+
+func roundCents(amount float64) float64 {
+	return roundTo(amount, 2)
+}
+
+/*roundTo rounds the given amount to the given decimal places, i.e. the ones of its currency*/
+func roundTo(amount float64, decimals int) float64 {
+
+	scale := math.Pow10(decimals)
+
+	return math.Round(amount*scale) / scale
+}
@@ -0,0 +1,114 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+func Test_Listing_types_exposures_and_prices_are_read(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	types, err := client.GetListingTypes(sdk.SiteMLA)
+
+	if err != nil || len(types) != 3 || types[0].ID != "free" || types[0].SiteID != sdk.SiteMLA {
+		log.Printf("Error: unexpected listing types %+v %v", types, err)
+		t.FailNow()
+	}
+
+	exposures, err := client.GetListingExposures(sdk.SiteMLA)
+
+	if err != nil || len(exposures) != 2 || !exposures[0].HomePage || exposures[1].PriorityInSearch != 4 {
+		log.Printf("Error: unexpected exposures %+v %v", exposures, err)
+		t.FailNow()
+	}
+
+	prices, err := client.GetListingPrices(sdk.SiteMLA, sdk.ListingPriceQuery{Price: 20000, CategoryID: "MLA1055"})
+
+	if err != nil || len(prices) != 3 || prices[1].ListingTypeID != "gold_pro" || prices[1].SaleFeeAmount != 3400 || prices[1].CurrencyID != "ARS" {
+		log.Printf("Error: unexpected listing prices %+v %v", prices, err)
+		t.FailNow()
+	}
+
+	prices, err = client.GetListingPrices(sdk.SiteMLA, sdk.ListingPriceQuery{Price: 20000, ListingTypeID: "gold_special"})
+
+	if err != nil || len(prices) != 1 || prices[0].SaleFeeAmount != 2600 {
+		log.Printf("Error: a single listing price was expected %+v %v", prices, err)
+		t.FailNow()
+	}
+}
+
+func Test_Fees_are_broken_down(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.SetSaleFee("gold_special", sdktest.SaleFee{Name: "Clásica", Percentage: 12.5, FixedFee: 1000, FixedFeeThreshold: 15000, ListingFee: 500})
+
+	fees, err := client.CalculateFees(sdk.SiteMLA, 9999.99, "MLA1055", "gold_special")
+
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	expected := sdk.FeeBreakdown{
+		Price:               9999.99,
+		CurrencyID:          "ARS",
+		ListingTypeID:       "gold_special",
+		PercentageFee:       12.5,
+		PercentageFeeAmount: 1250,
+		FixedFee:            1000,
+		SaleFee:             2250,
+		ListingFee:          500,
+		NetAmount:           7749.99,
+	}
+
+	if *fees != expected {
+		log.Printf("Error: expected %+v obtained %+v", expected, *fees)
+		t.FailNow()
+	}
+
+	//chilean pesos have no decimals
+	fees, err = client.CalculateFees(sdk.SiteMLC, 9999, "MLC1055", "gold_special")
+
+	if err != nil || fees.CurrencyID != "CLP" || fees.PercentageFeeAmount != 1250 || fees.SaleFee != 2250 || fees.NetAmount != 7749 {
+		log.Printf("Error: unexpected fees in CLP %+v %v", fees, err)
+		t.FailNow()
+	}
+
+	_, err = client.CalculateFees(sdk.SiteMLA, 100, "MLA1055", "gold_unknown")
+
+	var apiError *sdk.APIError
+	if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusNotFound {
+		log.Printf("Error: expected a not found APIError obtained %v", err)
+		t.FailNow()
+	}
+
+	if _, err := client.CalculateFees(sdk.SiteMLA, 100, "MLA1055", ""); !errors.Is(err, sdk.ErrListingTypeNotFound) {
+		log.Printf("Error: expected ErrListingTypeNotFound obtained %v", err)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+)
+
+const redacted = "REDACTED"
+
+/*
+sensitiveKeys are the query params and log attributes whose values are never written to the logs.
+*/
+var sensitiveKeys = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"client_secret": true,
+	"code":          true,
+}
+
+/*sensitiveParams matches a sensitive param within any text, i.e. the message of an error holding a URL*/
+var sensitiveParams = regexp.MustCompile(`\b(access_token|refresh_token|client_secret|code)=[^&\s"']*`)
+
+var discardLogger = slog.New(discardHandler{})
+
+/*
+newLogger wraps the handler of the given logger so every record goes through the redaction.
+If no logger is given, nothing is logged at all.
+*/
+func newLogger(logger *slog.Logger) *slog.Logger {
+
+	if logger == nil {
+		return discardLogger
+	}
+
+	if _, ok := logger.Handler().(redactingHandler); ok {
+		return logger
+	}
+
+	return slog.New(redactingHandler{next: logger.Handler()})
+}
+
+/*
+redactText replaces the value of any sensitive param found within the given text,
+i.e. a URL, a form encoded body or the message of an error holding a URL.
+*/
+func redactText(text string) string {
+	return sensitiveParams.ReplaceAllString(text, "${1}="+redacted)
+}
+
+/*
+Redact replaces the access tokens, refresh tokens, client secrets and codes found within the given text,
+i.e. the message of an error returned by the Client, so it can be written to traces or metrics.
+*/
+func Redact(text string) string {
+	return redactText(text)
+}
+
+/*
+redactingHandler is a slog.Handler which scrubs tokens, secrets and codes before delegating
+the record to the next handler.
+*/
+type redactingHandler struct {
+	next slog.Handler
+}
+
+func (h redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h redactingHandler) Handle(ctx context.Context, record slog.Record) error {
+
+	scrubbed := slog.NewRecord(record.Time, record.Level, redactText(record.Message), record.PC)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		scrubbed.AddAttrs(redactAttr(attr))
+		return true
+	})
+
+	return h.next.Handle(ctx, scrubbed)
+}
+
+func (h redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+
+	scrubbed := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		scrubbed = append(scrubbed, redactAttr(attr))
+	}
+
+	return redactingHandler{next: h.next.WithAttrs(scrubbed)}
+}
+
+func (h redactingHandler) WithGroup(name string) slog.Handler {
+	return redactingHandler{next: h.next.WithGroup(name)}
+}
+
+func redactAttr(attr slog.Attr) slog.Attr {
+
+	if sensitiveKeys[attr.Key] {
+		return slog.String(attr.Key, redacted)
+	}
+
+	value := attr.Value.Resolve()
+
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, redactText(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		scrubbed := make([]any, 0, len(group))
+		for _, member := range group {
+			scrubbed = append(scrubbed, redactAttr(member))
+		}
+		return slog.Group(attr.Key, scrubbed...)
+	case slog.KindAny:
+		//errors, as the *url.Error returned by the http client, stringers and structs may hold a whole URL,
+		//so they are written as text whenever it has to be redacted
+		var text string
+		switch any := value.Any().(type) {
+		case error:
+			text = any.Error()
+		case fmt.Stringer:
+			text = any.String()
+		default:
+			text = fmt.Sprint(any)
+		}
+		if scrubbed := redactText(text); scrubbed != text {
+			return slog.String(attr.Key, scrubbed)
+		}
+	}
+
+	return slog.Attr{Key: attr.Key, Value: value}
+}
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
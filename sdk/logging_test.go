@@ -0,0 +1,146 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func Test_redactText_hides_tokens_secrets_and_codes(t *testing.T) {
+
+	url := redactText(APIURL + "/oauth/token?grant_type=authorization_code&client_secret=secret&code=TG-123&access_token=APP_USR-1")
+
+	if strings.Contains(url, "secret&") || strings.Contains(url, "TG-123") || strings.Contains(url, "APP_USR-1") {
+		log.Printf("Error: url was not redacted %s", url)
+		t.FailNow()
+	}
+
+	if !strings.Contains(url, "grant_type=authorization_code") {
+		log.Printf("Error: non sensitive params should be kept %s", url)
+		t.FailNow()
+	}
+}
+
+func Test_API_calls_are_logged_with_request_attributes_and_without_tokens(t *testing.T) {
+
+	var output bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := newTestClient(CLIENT_ID, USER_CODE, CLIENT_SECRET, "https://www.example.com", API_TEST)
+
+	if err != nil {
+		log.Printf("Error during Client instantation %s\n", err)
+		t.FailNow()
+	}
+
+	client.logger = newLogger(logger)
+	client.logger.Debug("token", "refresh_token", client.auth.RefreshToken)
+
+	if _, err := client.Get("/users/me?access_token=leaked"); err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	logs := output.String()
+
+	for _, expected := range []string{"method=GET", "path=", "/users/me", "status=200", "latency="} {
+		if !strings.Contains(logs, expected) {
+			log.Printf("Error: %s was not logged\n%s", expected, logs)
+			t.FailNow()
+		}
+	}
+
+	if strings.Contains(logs, "leaked") || strings.Contains(logs, "valid refresh token") {
+		log.Printf("Error: tokens were logged\n%s", logs)
+		t.FailNow()
+	}
+}
+
+func Test_errors_holding_urls_are_logged_without_secrets(t *testing.T) {
+
+	var output bytes.Buffer
+	logger := newLogger(slog.New(slog.NewTextHandler(&output, nil)))
+
+	err := &url.Error{
+		Op:  "Post",
+		URL: APIURL + "/oauth/token?grant_type=authorization_code&client_id=1&client_secret=SUPERSECRET&code=TG-SECRETCODE",
+		Err: errors.New("connection refused"),
+	}
+
+	logger.Error("authorization failed", "error", err)
+
+	logs := output.String()
+
+	if strings.Contains(logs, "SUPERSECRET") || strings.Contains(logs, "TG-SECRETCODE") {
+		log.Printf("Error: secrets were logged\n%s", logs)
+		t.FailNow()
+	}
+
+	if !strings.Contains(logs, "client_id=1") || !strings.Contains(logs, "connection refused") {
+		log.Printf("Error: the error was not logged\n%s", logs)
+		t.FailNow()
+	}
+}
+
+func Test_messages_texts_stringers_and_values_are_logged_without_secrets(t *testing.T) {
+
+	var output bytes.Buffer
+	logger := newLogger(slog.New(slog.NewTextHandler(&output, nil)))
+
+	callback, _ := url.Parse("http://localhost:8080/callback?code=TG-SECRETCODE&state=xyz")
+	form := struct{ Body string }{Body: "grant_type=refresh_token&refresh_token=TG-SECRETREFRESH"}
+
+	logger.Info("refreshing with refresh_token=TG-SECRETMESSAGE",
+		"body", "client_id=1&client_secret=SUPERSECRET",
+		"callback", callback,
+		"form", form,
+		"question", "is it ready? yes")
+
+	logs := output.String()
+
+	for _, secret := range []string{"TG-SECRETCODE", "TG-SECRETREFRESH", "TG-SECRETMESSAGE", "SUPERSECRET"} {
+		if strings.Contains(logs, secret) {
+			log.Printf("Error: %s was logged\n%s", secret, logs)
+			t.FailNow()
+		}
+	}
+
+	for _, expected := range []string{"client_id=1", "state=xyz", "grant_type=refresh_token", "is it ready? yes"} {
+		if !strings.Contains(logs, expected) {
+			log.Printf("Error: %s was not logged\n%s", expected, logs)
+			t.FailNow()
+		}
+	}
+}
+
+func Test_public_clients_keep_the_configured_logger(t *testing.T) {
+
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	client, err := MeliClient(MeliConfig{ClientID: CLIENT_ID, Logger: logger})
+
+	if err != nil || client == publicClient || client.log() == discardLogger {
+		log.Printf("Error: the logger of a public client was dropped %v", err)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+)
+
+/*
+ErrLoginState is returned by LoopbackLogin, along with the error of ctx, when it gave up after receiving only redirects
+which did not carry the state sent to MercadoLibre. Those redirects are answered with 400 while the login keeps waiting.
+*/
+var ErrLoginState = errors.New("the state received does not match the one sent")
+
+/*
+LoopbackConfig configures LoopbackLogin. MeliConfig.CallBackURL has to be a loopback http URL registered for the application,
+i.e. http://localhost:8080/callback or http://127.0.0.1:8080/callback. If its port is 0, any free port is used, which is only useful when MercadoLibre
+is not involved, i.e. in tests.
+*/
+type LoopbackConfig struct {
+	MeliConfig
+
+	//SiteID is the site the user logs in to. It defaults to SiteMLA.
+	SiteID string
+
+	//OpenURL is called with the URL the user has to visit, i.e. to open a browser or print it. It is required.
+	OpenURL func(authURL string) error
+
+	//Manager keeps the client obtained. It is required.
+	Manager *ClientManager
+
+	//SuccessMessage is shown in the browser once the code was received.
+	SuccessMessage string
+}
+
+/*
+LoopbackLogin obtains a client for desktop and CLI apps, which cannot receive the OAuth redirect on a public server.
+It listens on the callback URL, sends the user to the auth URL with a random state, waits for MercadoLibre to redirect
+the browser back with the code, checks the state and exchanges the code. Redirects without the state are answered
+with 400 and the login keeps waiting. It returns when the client is ready, or when ctx is done.
+*/
+func LoopbackLogin(ctx context.Context, config LoopbackConfig) (*Client, error) {
+
+	if config.OpenURL == nil {
+		return nil, errors.New("LoopbackConfig.OpenURL is required")
+	}
+
+	if config.Manager == nil {
+		return nil, errors.New("LoopbackConfig.Manager is required")
+	}
+
+	if config.SiteID == "" {
+		config.SiteID = SiteMLA
+	}
+
+	if config.SuccessMessage == "" {
+		config.SuccessMessage = "You are logged in, you can close this window."
+	}
+
+	site, ok := LookupSite(config.SiteID)
+	if !ok {
+		return nil, ErrUnknownSite
+	}
+
+	callback, err := url.Parse(config.CallBackURL)
+	if err != nil || callback.Scheme != "http" || !isLoopback(callback.Hostname()) {
+		return nil, fmt.Errorf("callback URL %q is not a loopback http URL", config.CallBackURL)
+	}
+
+	address := callback.Host
+	if callback.Port() == "" {
+		address = net.JoinHostPort(callback.Hostname(), "80")
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("cannot listen on %s for the callback: %s", address, err)
+	}
+
+	//The port is known once listening, when any free port was requested.
+	if callback.Port() == "0" {
+		callback.Host = listener.Addr().String()
+		config.CallBackURL = callback.String()
+	}
+
+	path := callback.Path
+	if path == "" {
+		path = "/"
+	}
+
+	state, err := newState()
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+
+	//forged is set once a redirect with a wrong or missing state was received
+	var forged atomic.Bool
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+
+		//a "/" pattern matches any path, i.e. the favicon requested by the browser, which must not abort the login
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+
+		query := r.URL.Query()
+
+		//anything able to reach the port may send a redirect, so only the one carrying the state ends the login
+		if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+			forged.Store(true)
+			http.Error(w, ErrLoginState.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var received result
+
+		switch {
+		case query.Get("error") != "":
+			received.err = fmt.Errorf("login failed: %s %s", query.Get("error"), query.Get("error_description"))
+		case query.Get("code") == "":
+			received.err = errors.New("login failed: the code is missing")
+		default:
+			received.code = query.Get("code")
+		}
+
+		if received.err != nil {
+			http.Error(w, received.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, config.SuccessMessage)
+		}
+
+		select {
+		case results <- received:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	if err := config.OpenURL(GetAuthURLWithState(config.ClientID, site.AuthURL, config.CallBackURL, state)); err != nil {
+		return nil, err
+	}
+
+	var received result
+
+	select {
+	case received = <-results:
+	case <-ctx.Done():
+		if forged.Load() {
+			return nil, fmt.Errorf("%w: %w", ErrLoginState, ctx.Err())
+		}
+		return nil, ctx.Err()
+	}
+
+	if received.err != nil {
+		return nil, received.err
+	}
+
+	config.UserCode = received.code
+
+	return config.Manager.Client(config.MeliConfig)
+}
+
+/*isLoopback returns true when the given host is localhost or a loopback address, i.e. 127.0.0.1 or ::1*/
+func isLoopback(host string) bool {
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+/*newState returns a random state for the auth URL*/
+func newState() (string, error) {
+
+	state := make([]byte, 16)
+	if _, err := rand.Read(state); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(state), nil
+}
@@ -0,0 +1,216 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+/*browser returns an OpenURL which follows the auth URL as MercadoLibre would, redirecting with the given code and state*/
+func browser(code string, state func(sent string) string) func(authURL string) error {
+
+	return func(authURL string) error {
+
+		parsed, err := url.Parse(authURL)
+		if err != nil {
+			return err
+		}
+
+		redirect := parsed.Query().Get("redirect_uri") + "?" + url.Values{
+			"code":  {code},
+			"state": {state(parsed.Query().Get("state"))},
+		}.Encode()
+
+		go http.Get(redirect)
+		return nil
+	}
+}
+
+func newLoopbackConfig(server *sdktest.Server, openURL func(string) error) sdk.LoopbackConfig {
+
+	config := sdk.LoopbackConfig{
+		MeliConfig: server.Config(""),
+		SiteID:     sdk.SiteMLB,
+		OpenURL:    openURL,
+		Manager:    sdk.NewClientManager(sdk.ManagerConfig{}),
+	}
+
+	config.CallBackURL = "http://127.0.0.1:0/callback"
+
+	return config
+}
+
+func Test_Loopback_login_returns_an_authorized_client(t *testing.T) {
+
+	server := sdktest.NewServer()
+	defer server.Close()
+	server.AddUser(sdktest.User{ID: testSellerID, Nickname: "TEST_SELLER"})
+
+	var authURL string
+	openURL := browser(server.NewCode(testSellerID), func(sent string) string { return sent })
+
+	config := newLoopbackConfig(server, func(u string) error {
+		authURL = u
+		return openURL(u)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, err := sdk.LoopbackLogin(ctx, config)
+
+	if err != nil || client.UserID() != testSellerID {
+		log.Printf("Error: login failed %v", err)
+		t.FailNow()
+	}
+
+	parsed, _ := url.Parse(authURL)
+
+	if parsed.Host != "auth.mercadolivre.com.br" || parsed.Query().Get("state") == "" || parsed.Query().Get("client_id") != "123456" {
+		log.Printf("Error: unexpected auth URL %s", authURL)
+		t.FailNow()
+	}
+
+	if _, ok := config.Manager.LookupUser(testSellerID); !ok {
+		log.Printf("Error: the client should be kept by the manager")
+		t.FailNow()
+	}
+}
+
+func Test_Loopback_login_ignores_other_paths_when_the_callback_is_the_root(t *testing.T) {
+
+	server := sdktest.NewServer()
+	defer server.Close()
+	server.AddUser(sdktest.User{ID: testSellerID, Nickname: "TEST_SELLER"})
+
+	openURL := browser(server.NewCode(testSellerID), func(sent string) string { return sent })
+
+	config := newLoopbackConfig(server, func(authURL string) error {
+
+		parsed, _ := url.Parse(authURL)
+		resp, err := http.Get(parsed.Query().Get("redirect_uri") + "favicon.ico")
+
+		if err != nil || resp.StatusCode != http.StatusNotFound {
+			return errors.New("the favicon should not be found")
+		}
+
+		resp.Body.Close()
+		return openURL(authURL)
+	})
+	config.CallBackURL = "http://127.0.0.1:0/"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if client, err := sdk.LoopbackLogin(ctx, config); err != nil || client.UserID() != testSellerID {
+		log.Printf("Error: login failed %v", err)
+		t.FailNow()
+	}
+}
+
+func Test_Loopback_login_answers_a_different_state_with_400_and_keeps_waiting(t *testing.T) {
+
+	server := sdktest.NewServer()
+	defer server.Close()
+	server.AddUser(sdktest.User{ID: testSellerID, Nickname: "TEST_SELLER"})
+
+	openURL := browser(server.NewCode(testSellerID), func(sent string) string { return sent })
+
+	config := newLoopbackConfig(server, func(authURL string) error {
+
+		parsed, _ := url.Parse(authURL)
+
+		for _, state := range []string{"forged", ""} {
+			resp, err := http.Get(parsed.Query().Get("redirect_uri") + "?" + url.Values{"code": {"TG-FORGED"}, "state": {state}}.Encode())
+
+			if err != nil || resp.StatusCode != http.StatusBadRequest {
+				return errors.New("a redirect without the state should be answered with 400")
+			}
+			resp.Body.Close()
+		}
+
+		return openURL(authURL)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if client, err := sdk.LoopbackLogin(ctx, config); err != nil || client.UserID() != testSellerID {
+		log.Printf("Error: login failed %v", err)
+		t.FailNow()
+	}
+}
+
+func Test_Loopback_login_gives_up_on_a_different_state_when_the_context_is_done(t *testing.T) {
+
+	server := sdktest.NewServer()
+	defer server.Close()
+	server.AddUser(sdktest.User{ID: testSellerID, Nickname: "TEST_SELLER"})
+
+	config := newLoopbackConfig(server, browser(server.NewCode(testSellerID), func(string) string { return "forged" }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if _, err := sdk.LoopbackLogin(ctx, config); !errors.Is(err, sdk.ErrLoginState) || !errors.Is(err, context.DeadlineExceeded) {
+		log.Printf("Error: expected ErrLoginState once the deadline is exceeded obtained %v", err)
+		t.FailNow()
+	}
+}
+
+func Test_Loopback_login_requires_a_loopback_callback(t *testing.T) {
+
+	server := sdktest.NewServer()
+	defer server.Close()
+
+	config := newLoopbackConfig(server, func(string) error { return nil })
+
+	for _, callback := range []string{"http://example.com:8080/callback", "http://0.0.0.0:0/callback", "https://localhost:8080/callback"} {
+
+		config.CallBackURL = callback
+
+		if _, err := sdk.LoopbackLogin(context.Background(), config); err == nil {
+			log.Printf("Error: %s should be rejected", callback)
+			t.FailNow()
+		}
+	}
+}
+
+func Test_Loopback_login_gives_up_when_the_context_is_done(t *testing.T) {
+
+	server := sdktest.NewServer()
+	defer server.Close()
+
+	config := newLoopbackConfig(server, func(string) error { return nil })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := sdk.LoopbackLogin(ctx, config); !errors.Is(err, context.DeadlineExceeded) {
+		log.Printf("Error: expected the deadline to be exceeded obtained %v", err)
+		t.FailNow()
+	}
+}
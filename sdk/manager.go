@@ -0,0 +1,312 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"container/list"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+ClientKeyFunc returns the key a ClientManager keeps an authorized client under.
+*/
+type ClientKeyFunc func(client *Client) string
+
+/*
+KeyByCode keys clients by application id and user code. This is the default key.
+*/
+func KeyByCode(client *Client) string {
+	return codeKey(client.id, client.code)
+}
+
+/*
+KeyByUserID keys clients by the id of the user they act on behalf of, so a user authorizing
+the application again replaces the client kept for it.
+*/
+func KeyByUserID(client *Client) string {
+	return strconv.FormatInt(client.UserID(), 10)
+}
+
+type ManagerConfig struct {
+	//Key returns the key a client is kept under. KeyByCode is used when nil.
+	Key ClientKeyFunc
+	//TTL evicts the clients which were not requested during that time. Zero means clients never expire.
+	TTL time.Duration
+	//MaxClients evicts the least recently requested client when exceeded. Zero means no limit.
+	MaxClients int
+}
+
+/*
+ClientManager keeps the authorized clients, so the same client is returned when the same
+application id and user code are provided. User codes can be exchanged only once, so a client
+has to be kept for as long as the application needs to call the private API on behalf of the user.
+*/
+type ClientManager struct {
+	mutex   sync.Mutex
+	config  ManagerConfig
+	clients map[string]*list.Element
+	byCode  map[string]*list.Element
+	byUser  map[int64]*list.Element
+	recent  *list.List
+	now     func() time.Time
+	//pending are the codes being exchanged, so a code is never exchanged twice while the mutex is released
+	pending map[string]*pendingClient
+}
+
+/*pendingClient is a client being authorized. done is closed once client or err are set.*/
+type pendingClient struct {
+	done   chan struct{}
+	client *Client
+	err    error
+}
+
+type managedClient struct {
+	key      string
+	codeKey  string
+	userID   int64
+	client   *Client
+	lastUsed time.Time
+}
+
+/*NewClientManager returns an empty ClientManager*/
+func NewClientManager(config ManagerConfig) *ClientManager {
+
+	if config.Key == nil {
+		config.Key = KeyByCode
+	}
+
+	return &ClientManager{
+		config:  config,
+		clients: make(map[string]*list.Element),
+		byCode:  make(map[string]*list.Element),
+		byUser:  make(map[int64]*list.Element),
+		recent:  list.New(),
+		now:     time.Now,
+		pending: make(map[string]*pendingClient),
+	}
+}
+
+/*
+Client returns the client kept for the application id and user code within config, or authorizes a new one.
+If the user code is empty, a client which is only able to query the public API is returned.
+*/
+func (manager *ClientManager) Client(config MeliConfig) (*Client, error) {
+
+	//If userCode is not provided, then a generic client is returned.
+	//This client can be used only to access public API
+	if strings.Compare(config.UserCode, "") == 0 {
+		return newPublicClient(config), nil
+	}
+
+	key := codeKey(config.ClientID, config.UserCode)
+
+	manager.mutex.Lock()
+
+	manager.evictExpired()
+
+	if element, ok := manager.byCode[key]; ok {
+		client := manager.touch(element)
+		manager.mutex.Unlock()
+		return client, nil
+	}
+
+	if pending, ok := manager.pending[key]; ok {
+		manager.mutex.Unlock()
+		<-pending.done
+		return pending.client, pending.err
+	}
+
+	pending := &pendingClient{done: make(chan struct{})}
+	manager.pending[key] = pending
+	manager.mutex.Unlock()
+
+	//the code is exchanged without holding the mutex, so other clients can be used meanwhile
+	pending.client, pending.err = newClient(config)
+
+	manager.mutex.Lock()
+	delete(manager.pending, key)
+	if pending.err == nil {
+		manager.add(pending.client)
+	}
+	manager.mutex.Unlock()
+
+	close(pending.done)
+
+	return pending.client, pending.err
+}
+
+/*Lookup returns the client kept under the given key*/
+func (manager *ClientManager) Lookup(key string) (*Client, bool) {
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.evictExpired()
+
+	element, ok := manager.clients[key]
+	if !ok {
+		return nil, false
+	}
+
+	return manager.touch(element), true
+}
+
+/*
+LookupUser returns the most recently authorized client acting on behalf of the given user,
+whatever the key it is kept under.
+*/
+func (manager *ClientManager) LookupUser(userID int64) (*Client, bool) {
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.evictExpired()
+
+	element, ok := manager.byUser[userID]
+	if !ok {
+		return nil, false
+	}
+
+	return manager.touch(element), true
+}
+
+/*Remove forgets the client kept under the given key, i.e. when the user revokes the application grant*/
+func (manager *ClientManager) Remove(key string) {
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	if element, ok := manager.clients[key]; ok {
+		manager.remove(element)
+	}
+}
+
+/*RemoveClient forgets the given client, if it is still kept by the manager*/
+func (manager *ClientManager) RemoveClient(client *Client) {
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	for _, element := range manager.clients {
+		if element.Value.(*managedClient).client == client {
+			manager.remove(element)
+			return
+		}
+	}
+}
+
+/*Len returns how many clients are being kept*/
+func (manager *ClientManager) Len() int {
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	return len(manager.clients)
+}
+
+/*Reset forgets every client*/
+func (manager *ClientManager) Reset() {
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.clients = make(map[string]*list.Element)
+	manager.byCode = make(map[string]*list.Element)
+	manager.byUser = make(map[int64]*list.Element)
+	manager.recent.Init()
+}
+
+/*
+add keeps the given client. A client already kept under the same key, i.e. the same user
+authorizing the application again, is replaced.
+*/
+func (manager *ClientManager) add(client *Client) {
+
+	key := manager.config.Key(client)
+
+	if element, ok := manager.clients[key]; ok {
+		manager.remove(element)
+	}
+
+	entry := &managedClient{
+		key:      key,
+		codeKey:  codeKey(client.id, client.code),
+		userID:   client.UserID(),
+		client:   client,
+		lastUsed: manager.now(),
+	}
+
+	element := manager.recent.PushFront(entry)
+	manager.clients[key] = element
+	manager.byCode[entry.codeKey] = element
+	client.manager = manager
+
+	if entry.userID != 0 {
+		manager.byUser[entry.userID] = element
+	}
+
+	if manager.config.MaxClients > 0 {
+		for manager.recent.Len() > manager.config.MaxClients {
+			manager.remove(manager.recent.Back())
+		}
+	}
+}
+
+func (manager *ClientManager) touch(element *list.Element) *Client {
+
+	entry := element.Value.(*managedClient)
+	entry.lastUsed = manager.now()
+	manager.recent.MoveToFront(element)
+
+	return entry.client
+}
+
+func (manager *ClientManager) remove(element *list.Element) {
+
+	entry := element.Value.(*managedClient)
+
+	delete(manager.clients, entry.key)
+	delete(manager.byCode, entry.codeKey)
+	manager.recent.Remove(element)
+
+	if manager.byUser[entry.userID] == element {
+		delete(manager.byUser, entry.userID)
+	}
+}
+
+func (manager *ClientManager) evictExpired() {
+
+	if manager.config.TTL <= 0 {
+		return
+	}
+
+	deadline := manager.now().Add(-manager.config.TTL)
+
+	for element := manager.recent.Back(); element != nil; element = manager.recent.Back() {
+		if element.Value.(*managedClient).lastUsed.After(deadline) {
+			return
+		}
+		manager.remove(element)
+	}
+}
+
+func codeKey(clientID int64, code string) string {
+	return strconv.FormatInt(clientID, 10) + code
+}
@@ -0,0 +1,237 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestConfig(code string) MeliConfig {
+	return MeliConfig{
+		ClientID:    CLIENT_ID,
+		UserCode:    code,
+		Secret:      CLIENT_SECRET,
+		CallBackURL: "http://www.example.com",
+		HTTPClient:  MockHttpClient{},
+	}
+}
+
+func Test_ClientManager_returns_the_same_client_for_the_same_code(t *testing.T) {
+
+	manager := NewClientManager(ManagerConfig{})
+
+	first, err := manager.Client(newTestConfig(USER_CODE))
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	second, _ := manager.Client(newTestConfig(USER_CODE))
+
+	if first != second || manager.Len() != 1 {
+		log.Printf("Error: the same client should have been returned")
+		t.FailNow()
+	}
+}
+
+func Test_ClientManager_evicts_the_least_recently_used_client(t *testing.T) {
+
+	manager := NewClientManager(ManagerConfig{MaxClients: 2})
+
+	first, _ := manager.Client(newTestConfig(USER_CODE))
+	manager.Client(newTestConfig("ANOTHER_CODE"))
+	manager.Client(newTestConfig(USER_CODE))
+	manager.Client(newTestConfig("AUTHORIZED_CLIENT"))
+
+	if manager.Len() != 2 {
+		log.Printf("Error: expected 2 clients obtained %d", manager.Len())
+		t.FailNow()
+	}
+
+	if _, ok := manager.Lookup(KeyByCode(first)); !ok {
+		log.Printf("Error: the most recently used client should have been kept")
+		t.FailNow()
+	}
+
+	if _, ok := manager.Lookup(codeKey(CLIENT_ID, "ANOTHER_CODE")); ok {
+		log.Printf("Error: the least recently used client should have been evicted")
+		t.FailNow()
+	}
+}
+
+func Test_ClientManager_evicts_clients_not_used_within_the_TTL(t *testing.T) {
+
+	now := time.Now()
+	manager := NewClientManager(ManagerConfig{TTL: time.Hour})
+	manager.now = func() time.Time { return now }
+
+	client, _ := manager.Client(newTestConfig(USER_CODE))
+
+	now = now.Add(2 * time.Hour)
+
+	if _, ok := manager.Lookup(KeyByCode(client)); ok || manager.Len() != 0 {
+		log.Printf("Error: the client should have expired")
+		t.FailNow()
+	}
+}
+
+func Test_ClientManager_uses_the_configured_key(t *testing.T) {
+
+	manager := NewClientManager(ManagerConfig{Key: func(client *Client) string { return "seller" }})
+
+	client, _ := manager.Client(newTestConfig(USER_CODE))
+
+	if found, ok := manager.Lookup("seller"); !ok || found != client {
+		log.Printf("Error: the client should have been kept under the configured key")
+		t.FailNow()
+	}
+
+	manager.Remove("seller")
+
+	if manager.Len() != 0 {
+		log.Printf("Error: the client should have been removed")
+		t.FailNow()
+	}
+}
+
+func Test_ClientManager_removes_a_client_whose_grant_was_revoked(t *testing.T) {
+
+	manager := NewClientManager(ManagerConfig{})
+
+	config := newTestConfig(USER_CODE)
+	config.HTTPClient = MockHttpClientRevokedGrant{}
+
+	client, err := manager.Client(config)
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	client.auth.ExpiresIn = 0
+
+	if _, err := client.Get("/users/me"); !isRevoked(err) {
+		log.Printf("Error: a revoked grant error was expected, obtained %v", err)
+		t.FailNow()
+	}
+
+	if manager.Len() != 0 {
+		log.Printf("Error: the revoked client should have been removed")
+		t.FailNow()
+	}
+}
+
+func Test_ClientManager_keyed_by_user_ID_replaces_the_client_when_the_user_authorizes_again(t *testing.T) {
+
+	manager := NewClientManager(ManagerConfig{Key: KeyByUserID})
+
+	first, _ := manager.Client(newTestConfig(USER_CODE))
+	second, _ := manager.Client(newTestConfig("ANOTHER_CODE"))
+
+	if first.UserID() != 214509008 {
+		log.Printf("Error: expected user id 214509008 obtained %d", first.UserID())
+		t.FailNow()
+	}
+
+	if found, ok := manager.Lookup("214509008"); !ok || found != second || manager.Len() != 1 {
+		log.Printf("Error: the newest client should have replaced the previous one")
+		t.FailNow()
+	}
+
+	if found, ok := manager.LookupUser(214509008); !ok || found != second {
+		log.Printf("Error: the client should be found by user id")
+		t.FailNow()
+	}
+}
+
+func Test_ClientManager_is_not_locked_while_a_code_is_exchanged_and_exchanges_it_once(t *testing.T) {
+
+	manager := NewClientManager(ManagerConfig{})
+
+	httpClient := &MockHttpClientSlowAuthorization{release: make(chan struct{})}
+	config := newTestConfig(USER_CODE)
+	config.HTTPClient = httpClient
+
+	clients := make(chan *Client, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			client, _ := manager.Client(config)
+			clients <- client
+		}()
+	}
+
+	//another client is created while the exchange is pending
+	if _, err := manager.Client(newTestConfig("ANOTHER_CODE")); err != nil || manager.Len() != 1 {
+		log.Printf("Error: the manager was locked while exchanging a code %v", err)
+		t.FailNow()
+	}
+
+	close(httpClient.release)
+
+	first, second := <-clients, <-clients
+
+	if first == nil || first != second || manager.Len() != 2 || httpClient.exchanges != 1 {
+		log.Printf("Error: the code should have been exchanged once, exchanges %d", httpClient.exchanges)
+		t.FailNow()
+	}
+}
+
+/*MockHttpClientRevokedGrant authorizes the code but rejects the refresh token*/
+type MockHttpClientRevokedGrant struct {
+	MockHttpClient
+}
+
+func (httpClient MockHttpClientRevokedGrant) Post(uri string, bodyType string, body io.Reader) (*http.Response, error) {
+
+	if strings.Contains(uri, "grant_type=refresh_token") {
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Status:     "400 Bad Request",
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte("{\"error\":\"invalid_grant\"}"))),
+		}, nil
+	}
+
+	return httpClient.MockHttpClient.Post(uri, bodyType, body)
+}
+
+/*MockHttpClientSlowAuthorization blocks the authorization of codes until release is closed*/
+type MockHttpClientSlowAuthorization struct {
+	MockHttpClient
+	release   chan struct{}
+	mutex     sync.Mutex
+	exchanges int
+}
+
+func (httpClient *MockHttpClientSlowAuthorization) Post(uri string, bodyType string, body io.Reader) (*http.Response, error) {
+
+	if strings.Contains(uri, "grant_type=authorization_code") {
+		httpClient.mutex.Lock()
+		httpClient.exchanges++
+		httpClient.mutex.Unlock()
+
+		<-httpClient.release
+	}
+
+	return httpClient.MockHttpClient.Post(uri, bodyType, body)
+}
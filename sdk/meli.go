@@ -31,12 +31,14 @@ package sdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -66,25 +68,31 @@ const (
 )
 
 var publicClient = &Client{apiURL: APIURL, auth: anonymous, httpClient: MeliHTTPClient{}, tokenRefresher: MeliTokenRefresher{}}
-var clientByUser map[string]*Client
-var clientByUserMutex sync.Mutex
 var anonymous = Authorization{}
-var authMutex = &sync.Mutex{}
 
-var debugEnable = false //Set this true if you want to see debug messages
+/*GetAuthURL function returns the URL for the user to authenticate and authorize*/
+func GetAuthURL(clientID int64, baseSite, callback string) string {
 
-func init() {
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
-	clientByUser = make(map[string]*Client)
+	authURL := newAuthorizationURL(baseSite + "/authorization")
+	authURL.addResponseType("code")
+	authURL.addClientId(clientID)
+	authURL.addRedirectURI(callback)
+
+	return authURL.string()
 }
 
-/*GetAuthURL function returns the URL for the user to authenticate and authorize*/
-func GetAuthURL(clientID int64, baseSite, callback string) string {
+/*
+GetAuthURLWithState returns the URL for the user to authenticate and authorize, with a state MercadoLibre sends back
+to the callback along with the code. Check the state received is the one sent, to be sure the redirect
+was started by your application.
+*/
+func GetAuthURLWithState(clientID int64, baseSite, callback, state string) string {
 
 	authURL := newAuthorizationURL(baseSite + "/authorization")
 	authURL.addResponseType("code")
 	authURL.addClientId(clientID)
 	authURL.addRedirectURI(callback)
+	authURL.addState(state)
 
 	return authURL.string()
 }
@@ -96,6 +104,19 @@ type MeliConfig struct {
 	CallBackURL    string
 	HTTPClient     HTTPClient
 	TokenRefresher TokenRefresher
+
+	//HTTPOptions is used to build the default HTTPClient when HTTPClient is nil.
+	HTTPOptions HTTPOptions
+
+	//Logger receives the SDK log records. Tokens, secrets and codes are always redacted.
+	//If it is nil, nothing is logged.
+	Logger *slog.Logger
+
+	//Instrumentation is notified about every API call and token refresh. It can be nil.
+	Instrumentation Instrumentation
+
+	//APIURL is the MercadoLibre API the client calls. It defaults to sdk.APIURL and is meant to point clients to a fake API in tests.
+	APIURL string
 }
 
 /*Meli function returns a Client which can be used to call mercadolibre API.
@@ -124,61 +145,120 @@ func Meli(clientID int64, userCode string, secret string, callBackURL string) (*
 	return MeliClient(config)
 }
 
+/*
+defaultClientManager keeps the clients returned by Meli and MeliClient, which never evicts them.
+It only exists for those functions, which predate ClientManager: every other API takes its ClientManager.
+*/
+var defaultClientManager = NewClientManager(ManagerConfig{})
+
 /**
 This function allows you to be more specific on the config you prefer giving to the sdk Client.
 In case you want to use your own HttpClient or your TokenRefresher policy, you can use the following.
+The client is kept by a package level ClientManager, so the same one is returned for the same application id
+and user code. Create your own ClientManager if you need eviction, a different key or to isolate your clients.
 */
 func MeliClient(config MeliConfig) (*Client, error) {
 
-	//If userCode is not provided, then a generic client is returned.
-	//This client can be used only to access public API
-	if strings.Compare(config.UserCode, "") == 0 {
-		return publicClient, nil
-	}
+	return defaultClientManager.Client(config)
+}
+
+/*
+newPublicClient returns a client which is only able to access the public API.
+The shared publicClient is returned unless another API URL, HTTP client, Logger or Instrumentation is configured.
+*/
+func newPublicClient(config MeliConfig) *Client {
 
-	//If we are here, userCode was provided, so a full client is going to be set up, to allow full access to either private
-	//and public API
-	clientByUserMutex.Lock()
-	defer clientByUserMutex.Unlock()
+	meliHTTPClient, ok := config.HTTPClient.(MeliHTTPClient)
+	sharedHTTPClient := config.HTTPClient == nil || (ok && meliHTTPClient.client == nil && meliHTTPClient.userAgent == "")
 
-	//The same client is going to be returned if the same applicationId and userCode is provided.
-	key := strconv.FormatInt(config.ClientID, 10) + config.UserCode
+	if (config.APIURL == "" || config.APIURL == APIURL) && sharedHTTPClient && config.HTTPOptions.isZero() && config.Logger == nil && config.Instrumentation == nil {
+		return publicClient
+	}
 
-	var client *Client
-	client = clientByUser[key]
+	if config.HTTPClient == nil {
+		config.HTTPClient = NewMeliHTTPClient(config.HTTPOptions)
+	}
 
-	if client == nil {
+	if config.APIURL == "" {
+		config.APIURL = APIURL
+	}
 
-		client = &Client{
-			id:             config.ClientID,
-			code:           config.UserCode,
-			secret:         config.Secret,
-			redirectURL:    config.CallBackURL,
-			apiURL:         APIURL,
-			httpClient:     config.HTTPClient,
-			tokenRefresher: config.TokenRefresher,
-		}
+	return &Client{
+		apiURL:          config.APIURL,
+		auth:            anonymous,
+		httpClient:      config.HTTPClient,
+		tokenRefresher:  MeliTokenRefresher{},
+		logger:          newLogger(config.Logger),
+		instrumentation: config.Instrumentation,
+	}
+}
 
-		if debugEnable {
-			log.Printf("Building a client: %p for clientid:%d code:%s\n", client, config.ClientID, config.UserCode)
-		}
+/*
+newClient builds a full client, which is able to access either private and public API, and exchanges
+the user code for its tokens.
+*/
+func newClient(config MeliConfig) (*Client, error) {
 
-		auth, err := client.authorize()
+	client := buildClient(config)
 
-		if err != nil {
-			if debugEnable {
-				log.Printf("error: %s", err.Error())
-			}
-			return nil, err
-		}
+	client.logger.Debug("building a client", "client_id", config.ClientID, "code", config.UserCode)
 
-		clientByUser[key] = client
-		client.auth = *auth
+	auth, err := client.authorize()
+
+	if err != nil {
+		client.logger.Error("authorization failed", "client_id", config.ClientID, "error", err)
+		return nil, err
 	}
 
+	client.auth = *auth
+
+	client.logger = client.logger.With("user_id", auth.UserID)
+
 	return client, nil
 }
 
+/*
+RestoreClient returns a client acting with an Authorization obtained before, i.e. one stored by a desktop or CLI app,
+so no user code is exchanged. Its token is refreshed when it expires, as with any other client.
+The returned client is not kept by any ClientManager.
+*/
+func RestoreClient(config MeliConfig, auth Authorization) *Client {
+
+	client := buildClient(config)
+	client.auth = auth
+	client.logger = client.logger.With("user_id", auth.UserID)
+
+	return client
+}
+
+/*buildClient fills in the defaults of config and returns a client which is not authorized yet*/
+func buildClient(config MeliConfig) *Client {
+
+	if config.HTTPClient == nil {
+		config.HTTPClient = NewMeliHTTPClient(config.HTTPOptions)
+	}
+
+	if config.TokenRefresher == nil {
+		config.TokenRefresher = MeliTokenRefresher{}
+	}
+
+	if config.APIURL == "" {
+		config.APIURL = APIURL
+	}
+
+	return &Client{
+		id:              config.ClientID,
+		code:            config.UserCode,
+		secret:          config.Secret,
+		redirectURL:     config.CallBackURL,
+		apiURL:          config.APIURL,
+		httpClient:      config.HTTPClient,
+		tokenRefresher:  config.TokenRefresher,
+		logger:          newLogger(config.Logger),
+		instrumentation: config.Instrumentation,
+	}
+}
+
 /**
 HTTP Methods
 Given that error handling for all the HTTP Methods is pretty the same, then an interface Callback is define, which is
@@ -189,28 +269,69 @@ type Callback interface {
 }
 
 func httpErrorHandler(client *Client, resource string, httpMethod Callback) (*http.Response, error) {
+	return callAPI(client, resource, httpMethod, 0)
+}
+
+/*
+callAPI executes the given callback over the resource, logging and recording the call.
+retries is the number of failed attempts which preceded this call, when the caller retries it, i.e. the BulkUpdater.
+*/
+func callAPI(client *Client, resource string, httpMethod Callback, retries int) (*http.Response, error) {
 
 	var apiURL *AuthorizationURL
 	var err error
 
-	if apiURL, err = getAuthorizedURL(client, resource); err != nil {
-		if debugEnable {
-			log.Printf("Error %s", err)
-		}
+	method := callbackMethod(httpMethod)
+	logger := client.log().With("method", method, "path", resource, "retries", retries)
+	recorder := client.instrument().StartCall(method, resource)
+
+	if retryRecorder, ok := recorder.(RetryRecorder); ok && retries > 0 {
+		retryRecorder.Retried(retries)
+	}
+
+	if apiURL, err = getAuthorizedURL(client, resource, recorder); err != nil {
+		logger.Error("could not build the authorized url", "error", err)
+		recorder.End(nil, err)
 		return nil, err
 	}
 
+	start := time.Now()
+
 	var resp *http.Response
 	if resp, err = httpMethod.Call(apiURL.string()); err != nil {
-		if debugEnable {
-			log.Printf("Error while calling url: %s \n Error: %s", apiURL.string(), err)
-		}
+		logger.Error("api call failed", "latency", time.Since(start), "error", err)
+		recorder.End(nil, err)
 		return nil, err
 	}
 
+	recorder.End(resp, nil)
+
+	level := slog.LevelDebug
+	if resp.StatusCode >= http.StatusInternalServerError {
+		level = slog.LevelWarn
+	}
+	logger.Log(context.Background(), level, "api call", "status", resp.StatusCode, "latency", time.Since(start))
+
 	return resp, nil
 }
 
+/*callbackMethod returns the HTTP method executed by the given callback, so it can be logged.*/
+func callbackMethod(httpMethod Callback) string {
+
+	switch httpMethod.(type) {
+	case HTTPGet:
+		return http.MethodGet
+	case HTTPPost:
+		return http.MethodPost
+	case HTTPPut:
+		return http.MethodPut
+	case HTTPDelete:
+		return http.MethodDelete
+	}
+
+	return ""
+}
+
 /*
 HTTP Methods to be called by httpErrorHandler
 */
@@ -225,10 +346,18 @@ func (callback HTTPGet) Call(url string) (*http.Response, error) {
 type HTTPPost struct {
 	httpClient HTTPClient
 	body       string
+	//bodyType is the Content-Type of the body, application/json when empty
+	bodyType string
 }
 
 func (callback HTTPPost) Call(url string) (*http.Response, error) {
-	return callback.httpClient.Post(url, "application/json", bytes.NewReader([]byte(callback.body)))
+
+	bodyType := callback.bodyType
+	if bodyType == "" {
+		bodyType = "application/json"
+	}
+
+	return callback.httpClient.Post(url, bodyType, bytes.NewReader([]byte(callback.body)))
 }
 
 type HTTPPut struct {
@@ -249,14 +378,31 @@ func (callback HTTPDelete) Call(url string) (*http.Response, error) {
 }
 
 type Client struct {
-	apiURL         string
-	id             int64
-	secret         string
-	code           string
-	redirectURL    string
-	auth           Authorization
-	httpClient     HTTPClient
-	tokenRefresher TokenRefresher
+	apiURL          string
+	id              int64
+	secret          string
+	code            string
+	redirectURL     string
+	auth            Authorization
+	httpClient      HTTPClient
+	tokenRefresher  TokenRefresher
+	logger          *slog.Logger
+	instrumentation Instrumentation
+
+	//authMutex avoids refreshing the token several times when it expires
+	authMutex sync.Mutex
+	//manager keeps this client, if any, so it can be removed once its grant is revoked
+	manager *ClientManager
+}
+
+/*log returns the client logger, falling back to one that discards everything.*/
+func (client *Client) log() *slog.Logger {
+
+	if client.logger == nil {
+		return discardLogger
+	}
+
+	return client.logger
 }
 
 /*
@@ -275,9 +421,7 @@ func (client *Client) authorize() (*Authorization, error) {
 	var resp *http.Response
 	var err error
 	if resp, err = client.httpClient.Post(authURL.string(), "application/json", *(new(io.Reader))); err != nil {
-		if debugEnable {
-			log.Printf("Error when posting: %s", err)
-		}
+		client.log().Error("error when posting the authorization code", "error", err)
 		return nil, err
 	}
 
@@ -290,9 +434,7 @@ func (client *Client) authorize() (*Authorization, error) {
 
 	authorization := new(Authorization)
 	if err := json.Unmarshal(body, authorization); err != nil {
-		if debugEnable {
-			log.Printf("Error while receiving the authorization %s %s", err.Error(), body)
-		}
+		client.log().Error("error while receiving the authorization", "error", err)
 		return nil, err
 	}
 
@@ -324,45 +466,83 @@ func (client *Client) Delete(resourcePath string) (*http.Response, error) {
 	return httpErrorHandler(client, resourcePath, HTTPDelete{httpClient: client.httpClient})
 }
 
-func (client Client) IsAuthorized() bool {
+func (client *Client) IsAuthorized() bool {
+
+	client.authMutex.Lock()
+	defer client.authMutex.Unlock()
 
 	return (client.auth != anonymous)
 }
 
+/*UserID returns the id of the MercadoLibre user this client acts on behalf of, or 0 if it is not authorized*/
+func (client *Client) UserID() int64 {
+
+	client.authMutex.Lock()
+	defer client.authMutex.Unlock()
+
+	return client.auth.UserID
+}
+
+/*
+Authorization returns a copy of the tokens the client is using, so they can be stored and the client
+restored later with RestoreClient
+*/
+func (client *Client) Authorization() Authorization {
+
+	client.authMutex.Lock()
+	defer client.authMutex.Unlock()
+
+	return client.auth
+}
+
+/*RefreshAuthorization refreshes the token of the client right away, even if it has not expired yet*/
+func (client *Client) RefreshAuthorization() error {
+
+	client.authMutex.Lock()
+	defer client.authMutex.Unlock()
+
+	if client.auth == anonymous {
+		return errors.New("the client is not authorized")
+	}
+
+	return client.refreshToken()
+}
+
 /*
 This method returns the URL + Token to be used by each HTTP request.
 If Token needs to be refreshed, then this method will send a POST to ML API to refresh it.
 */
-func getAuthorizedURL(client *Client, resourcePath string) (*AuthorizationURL, error) {
+func getAuthorizedURL(client *Client, resourcePath string, recorder CallRecorder) (*AuthorizationURL, error) {
 
 	finalURL := newAuthorizationURL(client.apiURL + resourcePath)
-	var err error
 
-	if client.auth != anonymous {
+	client.authMutex.Lock()
+	defer client.authMutex.Unlock()
 
-		authMutex.Lock()
+	if client.auth != anonymous {
 
 		if client.auth.isExpired() {
 
-			if debugEnable {
-				log.Printf("Token has expired....Refreshing it...\n")
-			}
+			client.log().Debug("token has expired, refreshing it", "received_at", client.auth.ReceivedAt, "expires_in", client.auth.ExpiresIn)
 
+			start := time.Now()
 			err := client.refreshToken()
+			recorder.TokenRefreshed(start, err)
 
 			if err != nil {
-				if debugEnable {
-					log.Printf("Error while refreshing token %s\n", err.Error())
+				client.log().Error("error while refreshing token", "error", err)
+
+				if isRevoked(err) && client.manager != nil {
+					client.manager.RemoveClient(client)
 				}
 				return nil, err
 			}
 		}
 
-		authMutex.Unlock()
 		finalURL.addAccessToken(client.auth.AccessToken)
 	}
 
-	return finalURL, err
+	return finalURL, nil
 }
 
 type Authorization struct {
@@ -372,12 +552,10 @@ type Authorization struct {
 	ReceivedAt   int64
 	RefreshToken string `json:"refresh_token"`
 	Scope        string `json:"scope"`
+	UserID       int64  `json:"user_id"`
 }
 
 func (auth Authorization) isExpired() bool {
-	if debugEnable {
-		log.Printf("received at:%d expires in: %d\n", auth.ReceivedAt, auth.ExpiresIn)
-	}
 	return ((auth.ReceivedAt + int64(auth.ExpiresIn)) <= (time.Now().Unix() + 60))
 }
 
@@ -417,6 +595,10 @@ func (u *AuthorizationURL) addResponseType(value string) {
 	u.add("response_type=" + url.QueryEscape(value))
 }
 
+func (u *AuthorizationURL) addState(value string) {
+	u.add("state=" + url.QueryEscape(value))
+}
+
 func (u *AuthorizationURL) addAccessToken(t string) {
 	u.add("access_token=" + url.QueryEscape(t))
 }
@@ -452,50 +634,192 @@ type HTTPClient interface {
 	Delete(url string, body io.Reader) (*http.Response, error)
 }
 
+/*
+HTTPOptions allows you to tune the http.Client used by MeliHTTPClient.
+Zero values are replaced by the defaults below, so timeouts are never infinite.
+*/
+type HTTPOptions struct {
+	//Client is used as is when provided. The rest of the options, except UserAgent, are ignored.
+	Client *http.Client
+	//Transport is used instead of the SDK one when provided. Pool, proxy and connect options are ignored.
+	Transport           http.RoundTripper
+	Timeout             time.Duration
+	ConnectTimeout      time.Duration
+	ReadTimeout         time.Duration
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	Proxy               func(*http.Request) (*url.URL, error)
+	UserAgent           string
+}
+
+const (
+	DefaultTimeout             = 60 * time.Second
+	DefaultConnectTimeout      = 10 * time.Second
+	DefaultReadTimeout         = 30 * time.Second
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 10
+	DefaultIdleConnTimeout     = 90 * time.Second
+	DefaultUserAgent           = "MELI-GOLANG-SDK"
+)
+
+var defaultHTTPClient = newHTTPClient(HTTPOptions{})
+
+func (options HTTPOptions) isZero() bool {
+	return options.Client == nil && options.Transport == nil && options.Proxy == nil &&
+		options.Timeout == 0 && options.ConnectTimeout == 0 && options.ReadTimeout == 0 &&
+		options.MaxIdleConns == 0 && options.MaxIdleConnsPerHost == 0 && options.IdleConnTimeout == 0 &&
+		options.UserAgent == ""
+}
+
+/*newHTTPClient builds an http.Client from the given options, filling in the defaults.*/
+func newHTTPClient(options HTTPOptions) *http.Client {
+
+	if options.Client != nil {
+		return options.Client
+	}
+
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	transport := options.Transport
+	if transport == nil {
+		transport = newTransport(options)
+	}
+
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
+
+func newTransport(options HTTPOptions) *http.Transport {
+
+	connectTimeout := options.ConnectTimeout
+	if connectTimeout <= 0 {
+		connectTimeout = DefaultConnectTimeout
+	}
+
+	readTimeout := options.ReadTimeout
+	if readTimeout <= 0 {
+		readTimeout = DefaultReadTimeout
+	}
+
+	maxIdleConns := options.MaxIdleConns
+	if maxIdleConns <= 0 {
+		maxIdleConns = DefaultMaxIdleConns
+	}
+
+	maxIdleConnsPerHost := options.MaxIdleConnsPerHost
+	if maxIdleConnsPerHost <= 0 {
+		maxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+
+	idleConnTimeout := options.IdleConnTimeout
+	if idleConnTimeout <= 0 {
+		idleConnTimeout = DefaultIdleConnTimeout
+	}
+
+	proxy := options.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+
+	return &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           (&net.Dialer{Timeout: connectTimeout, KeepAlive: 30 * time.Second}).DialContext,
+		TLSHandshakeTimeout:   connectTimeout,
+		ResponseHeaderTimeout: readTimeout,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		IdleConnTimeout:       idleConnTimeout,
+	}
+}
+
+/*
+MeliHTTPClient is the default HTTPClient implementation.
+Its zero value is ready to use and relies on a shared http.Client built with the default HTTPOptions.
+*/
 type MeliHTTPClient struct {
+	client    *http.Client
+	userAgent string
+}
+
+/*NewMeliHTTPClient returns a MeliHTTPClient configured with the given options*/
+func NewMeliHTTPClient(options HTTPOptions) MeliHTTPClient {
+	return MeliHTTPClient{client: newHTTPClient(options), userAgent: options.UserAgent}
 }
 
 func (httpClient MeliHTTPClient) Get(url string) (*http.Response, error) {
-	return http.Get(url)
+
+	return httpClient.executeHTTPRequest(http.MethodGet, url, "", nil)
 }
 
 func (httpClient MeliHTTPClient) Post(url string, bodyType string, body io.Reader) (*http.Response, error) {
 
-	return http.Post(url, bodyType, body)
+	return httpClient.executeHTTPRequest(http.MethodPost, url, bodyType, body)
 }
 
 func (httpClient MeliHTTPClient) Put(url string, body io.Reader) (*http.Response, error) {
 
-	return httpClient.executeHTTPRequest(http.MethodPut, url, body)
+	return httpClient.executeHTTPRequest(http.MethodPut, url, "", body)
 }
 
 func (httpClient MeliHTTPClient) Delete(url string, body io.Reader) (*http.Response, error) {
 
-	return httpClient.executeHTTPRequest(http.MethodDelete, url, body)
+	return httpClient.executeHTTPRequest(http.MethodDelete, url, "", body)
 
 }
 
-func (httpClient MeliHTTPClient) executeHTTPRequest(method string, url string, body io.Reader) (*http.Response, error) {
+func (httpClient MeliHTTPClient) executeHTTPRequest(method string, url string, bodyType string, body io.Reader) (*http.Response, error) {
 
 	req, err := http.NewRequest(method, url, body)
 
 	if err != nil {
-		if debugEnable {
-			log.Printf("Error when creating %s request %s.", method, err.Error())
-		}
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	if bodyType != "" {
+		req.Header.Set("Content-Type", bodyType)
+	}
 
-	if err != nil {
-		if debugEnable {
-			log.Printf("Error while calling url: %s\n Error: %s", url, err.Error())
-		}
-		return nil, err
+	userAgent := httpClient.userAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
 	}
+	req.Header.Set("User-Agent", userAgent)
 
-	return resp, nil
+	client := httpClient.client
+	if client == nil {
+		client = defaultHTTPClient
+	}
+
+	return client.Do(req)
+}
+
+/*
+TokenRefreshError is returned by MeliTokenRefresher when the API does not return a new token.
+*/
+type TokenRefreshError struct {
+	StatusCode int
+	Status     string
+}
+
+func (err *TokenRefreshError) Error() string {
+	return "Refreshing token returned status code " + err.Status
+}
+
+/*
+isRevoked returns true when the refresh token was rejected, i.e. the user revoked the grant or it expired.
+*/
+func isRevoked(err error) bool {
+
+	var refreshError *TokenRefreshError
+
+	if !errors.As(err, &refreshError) {
+		return false
+	}
+
+	return refreshError.StatusCode == http.StatusBadRequest || refreshError.StatusCode == http.StatusUnauthorized
 }
 
 /**TokenRefresher is an interface which allows you to implement your own authentication/authorization mechanism.*/
@@ -525,30 +849,25 @@ func (refresher MeliTokenRefresher) RefreshToken(client *Client) error {
 	var err error
 
 	if resp, err = client.httpClient.Post(authorizationURL.string(), "application/json", *(new(io.Reader))); err != nil {
-		if debugEnable {
-			log.Printf("Error: %s\n", err.Error())
-		}
+		client.log().Error("error when posting the refresh token", "error", err)
 		return err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return errors.New("Refreshing token returned status code " + resp.Status)
+		resp.Body.Close()
+		return &TokenRefreshError{StatusCode: resp.StatusCode, Status: resp.Status}
 	}
 
 	body, err := ioutil.ReadAll(resp.Body)
 	resp.Body.Close()
 
 	if err := json.Unmarshal(body, &(client.auth)); err != nil {
-		if debugEnable {
-			log.Printf("Error while receiving the authorization %s %s", err.Error(), body)
-		}
+		client.log().Error("error while receiving the refreshed authorization", "error", err)
 		return err
 	}
 
 	client.auth.ReceivedAt = time.Now().Unix()
 
-	if debugEnable {
-		log.Printf("auth received at: %d expires in:%d\n", client.auth.ReceivedAt, client.auth.ExpiresIn)
-	}
+	client.log().Debug("token refreshed", "received_at", client.auth.ReceivedAt, "expires_in", client.auth.ExpiresIn)
 	return nil
 }
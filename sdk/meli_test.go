@@ -171,6 +171,29 @@ func Test_MeliTokenRefresher_Returns_An_Error_When_Authorization_Returns_A_HTTP_
 
 }
 
+func Test_MeliTokenRefresher_Closes_The_Body_Of_A_Rejected_Refresh(t *testing.T) {
+
+	client, err := newTestClient(CLIENT_ID, USER_CODE, CLIENT_SECRET, "https://www.example.com", API_TEST)
+
+	if err != nil {
+		log.Printf("Error during Client instantation %s\n", err)
+		t.FailNow()
+	}
+
+	body := &recordingBody{Reader: bytes.NewReader([]byte("{\"error\":\"invalid_grant\"}"))}
+	client.httpClient = MockHttpClientRefreshRejected{body: body}
+
+	if err := (MeliTokenRefresher{}).RefreshToken(client); !isRevoked(err) {
+		log.Printf("Error: the refresh should have been rejected %v", err)
+		t.FailNow()
+	}
+
+	if !body.closed {
+		log.Printf("Error: the response body was not closed")
+		t.FailNow()
+	}
+}
+
 func Test_Return_Authorized_FALSE_When_Client_Is_NOT_Authorized(t *testing.T) {
 
 	client, _ := Meli(CLIENT_ID, "", "", "www.example.com/me")
@@ -501,7 +524,8 @@ func (httpClient MockHttpClient) Post(uri string, bodyType string, body io.Reade
 						"\"token_type\":\"bearer\"," +
 						"\"expires_in\":10800," +
 						"\"refresh_token\":\"valid refresh token\"," +
-						"\"scope\":\"write read\"}")))
+						"\"scope\":\"write read\"," +
+						"\"user_id\":214509008}")))
 
 			}
 
@@ -612,7 +636,7 @@ func (httpClient MockHttpClientPostNonOKStatusCode) Post(uri string, bodyType st
 
 	httpResponse := http.Response{}
 	httpResponse.StatusCode = http.StatusForbidden
-	return new(http.Response), nil
+	return &http.Response{Body: ioutil.NopCloser(bytes.NewReader(nil))}, nil
 }
 func (httpClient MockHttpClientPostNonOKStatusCode) Get(url string) (*http.Response, error) {
 	return nil, nil
@@ -625,3 +649,73 @@ func (httpClient MockHttpClientPostNonOKStatusCode) Delete(uri string, body io.R
 func (httpClient MockHttpClientPostNonOKStatusCode) Put(uri string, body io.Reader) (*http.Response, error) {
 	return nil, nil
 }
+
+func Test_MeliHTTPClient_uses_the_given_transport_and_user_agent(t *testing.T) {
+
+	transport := &recordingTransport{}
+	httpClient := NewMeliHTTPClient(HTTPOptions{Transport: transport, UserAgent: "my-app/1.0"})
+
+	resp, err := httpClient.Post(API_TEST+"/items", "application/json", strings.NewReader("{}"))
+
+	if err != nil || resp.StatusCode != http.StatusOK {
+		log.Printf("Error: the request should have been sent through the given transport %v", err)
+		t.FailNow()
+	}
+
+	if transport.request == nil || transport.request.Header.Get("User-Agent") != "my-app/1.0" {
+		log.Printf("Error: User-Agent header was not set")
+		t.FailNow()
+	}
+
+	if transport.request.Header.Get("Content-Type") != "application/json" {
+		log.Printf("Error: Content-Type header was not set")
+		t.FailNow()
+	}
+}
+
+func Test_MeliHTTPClient_defaults_are_not_infinite(t *testing.T) {
+
+	client := newHTTPClient(HTTPOptions{})
+
+	if client.Timeout != DefaultTimeout {
+		log.Printf("Error: expected timeout %s obtained %s", DefaultTimeout, client.Timeout)
+		t.FailNow()
+	}
+
+	transport, ok := client.Transport.(*http.Transport)
+
+	if !ok || transport.ResponseHeaderTimeout != DefaultReadTimeout || transport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost {
+		log.Printf("Error: transport was not built with the default options")
+		t.FailNow()
+	}
+}
+
+type recordingTransport struct {
+	request *http.Request
+}
+
+func (transport *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport.request = req
+	return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(nil)), Request: req}, nil
+}
+
+/*MockHttpClientRefreshRejected rejects any refresh token with the given body*/
+type MockHttpClientRefreshRejected struct {
+	MockHttpClient
+	body *recordingBody
+}
+
+func (httpClient MockHttpClientRefreshRejected) Post(uri string, bodyType string, body io.Reader) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusBadRequest, Status: "400 Bad Request", Body: httpClient.body}, nil
+}
+
+/*recordingBody records whether it was closed*/
+type recordingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (body *recordingBody) Close() error {
+	body.closed = true
+	return nil
+}
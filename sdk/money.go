@@ -0,0 +1,266 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+/*ErrCurrencyMismatch is returned when adding or subtracting amounts of different currencies*/
+var ErrCurrencyMismatch = errors.New("money: currencies do not match")
+
+/*
+Money is an amount of a currency. The amount is kept as an integer number of the smallest unit of the currency,
+i.e. cents, so adding and multiplying amounts never accumulates the rounding errors of float64.
+Its zero value is zero of no currency.
+*/
+type Money struct {
+	//Units is the amount in the smallest unit of the currency, i.e. cents when Decimals is 2
+	Units int64
+	//Decimals are the decimal places of the currency, see DecimalPlaces
+	Decimals   int
+	CurrencyID string
+}
+
+/*NewMoney returns the given amount, rounded to the decimal places of the currency, half away from zero*/
+func NewMoney(amount float64, currencyID string) Money {
+
+	decimals := DecimalPlaces(currencyID)
+
+	return Money{Units: int64(math.Round(amount * math.Pow10(decimals))), Decimals: decimals, CurrencyID: currencyID}
+}
+
+/*
+ParseMoney parses a decimal amount, i.e. "1234.5", without going through float64 unless it has an exponent.
+Amounts with more decimals than the currency has are rounded, half away from zero.
+*/
+func ParseMoney(amount string, currencyID string) (Money, error) {
+
+	if strings.ContainsAny(amount, "eE") {
+		value, err := strconv.ParseFloat(amount, 64)
+		if err != nil {
+			return Money{}, fmt.Errorf("money: invalid amount %q", amount)
+		}
+		return NewMoney(value, currencyID), nil
+	}
+
+	negative := strings.HasPrefix(amount, "-")
+	digits := strings.TrimPrefix(strings.TrimPrefix(amount, "-"), "+")
+
+	units, decimals := digits, ""
+	if dot := strings.IndexByte(digits, '.'); dot >= 0 {
+		units, decimals = digits[:dot], digits[dot+1:]
+	}
+
+	if units == "" && decimals == "" || !onlyDigits(units) || !onlyDigits(decimals) {
+		return Money{}, fmt.Errorf("money: invalid amount %q", amount)
+	}
+
+	places := DecimalPlaces(currencyID)
+
+	roundUp := len(decimals) > places && decimals[places] >= '5'
+	decimals = (decimals + strings.Repeat("0", places))[:places]
+
+	value, err := strconv.ParseInt("0"+units+decimals, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("money: invalid amount %q", amount)
+	}
+
+	if roundUp {
+		value++
+	}
+
+	if negative {
+		value = -value
+	}
+
+	return Money{Units: value, Decimals: places, CurrencyID: currencyID}, nil
+}
+
+func onlyDigits(s string) bool {
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*Float64 returns the amount as a float64, i.e. to send it to the API*/
+func (money Money) Float64() float64 {
+	return float64(money.Units) / math.Pow10(money.Decimals)
+}
+
+/*Amount returns the amount with the decimal places of its currency, i.e. "1234.50" or "1234" for CLP*/
+func (money Money) Amount() string {
+
+	units := money.Units
+	sign := ""
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+
+	if money.Decimals <= 0 {
+		return fmt.Sprintf("%s%d", sign, units)
+	}
+
+	scale := pow10(money.Decimals)
+
+	return fmt.Sprintf("%s%d.%0*d", sign, units/scale, money.Decimals, units%scale)
+}
+
+/*String returns the currency and the amount, i.e. "ARS 1234.50"*/
+func (money Money) String() string {
+	return strings.TrimSpace(money.CurrencyID + " " + money.Amount())
+}
+
+/*IsZero tells whether the amount is zero, whatever the currency*/
+func (money Money) IsZero() bool {
+	return money.Units == 0
+}
+
+/*Add returns the sum of both amounts. Zero of no currency can be added to any amount.*/
+func (money Money) Add(other Money) (Money, error) {
+
+	currencyID, err := sameCurrency(money, other)
+	if err != nil {
+		return Money{}, err
+	}
+
+	decimals := max(money.Decimals, other.Decimals)
+
+	return Money{Units: money.rescale(decimals) + other.rescale(decimals), Decimals: decimals, CurrencyID: currencyID}, nil
+}
+
+/*Sub returns the difference of both amounts*/
+func (money Money) Sub(other Money) (Money, error) {
+
+	other.Units = -other.Units
+	return money.Add(other)
+}
+
+/*Mul returns the amount multiplied by a quantity, i.e. the subtotal of some units*/
+func (money Money) Mul(quantity int) Money {
+
+	money.Units *= int64(quantity)
+	return money
+}
+
+/*rescale returns the units of the amount expressed with more decimal places*/
+func (money Money) rescale(decimals int) int64 {
+	return money.Units * pow10(decimals-money.Decimals)
+}
+
+func pow10(n int) int64 {
+
+	result := int64(1)
+	for i := 0; i < n; i++ {
+		result *= 10
+	}
+
+	return result
+}
+
+func sameCurrency(money Money, other Money) (string, error) {
+
+	switch {
+	case money.CurrencyID == other.CurrencyID:
+		return money.CurrencyID, nil
+	case money.CurrencyID == "" && money.Units == 0:
+		return other.CurrencyID, nil
+	case other.CurrencyID == "" && other.Units == 0:
+		return money.CurrencyID, nil
+	}
+
+	return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, money.CurrencyID, other.CurrencyID)
+}
+
+/*MarshalJSON encodes the money as {"amount": 1234.5, "currency_id": "ARS"}, writing the amount as an exact decimal*/
+func (money Money) MarshalJSON() ([]byte, error) {
+
+	return json.Marshal(struct {
+		Amount     json.Number `json:"amount"`
+		CurrencyID string      `json:"currency_id"`
+	}{json.Number(money.Amount()), money.CurrencyID})
+}
+
+func (money *Money) UnmarshalJSON(data []byte) error {
+
+	var value struct {
+		Amount     json.Number `json:"amount"`
+		CurrencyID string      `json:"currency_id"`
+	}
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	parsed, err := decodeAmount(value.Amount, value.CurrencyID)
+	if err != nil {
+		return err
+	}
+
+	*money = parsed
+	return nil
+}
+
+/*
+decodeAmount returns an amount the API sent as a plain JSON number along with the id of its currency,
+as the price of an item. A missing amount is zero.
+*/
+func decodeAmount(amount json.Number, currencyID string) (Money, error) {
+
+	if amount == "" {
+		return Money{Decimals: DecimalPlaces(currencyID), CurrencyID: currencyID}, nil
+	}
+
+	return ParseMoney(amount.String(), currencyID)
+}
+
+/*Subtotal returns the price of every unit of the item bought*/
+func (orderItem OrderItem) Subtotal() Money {
+	return orderItem.UnitPrice.Mul(orderItem.Quantity)
+}
+
+/*ItemsTotal returns the sum of the subtotals of the items of the order, in the currency of the order*/
+func (order Order) ItemsTotal() (Money, error) {
+
+	total := Money{Decimals: DecimalPlaces(order.CurrencyID), CurrencyID: order.CurrencyID}
+
+	for _, orderItem := range order.OrderItems {
+
+		subtotal := orderItem.Subtotal()
+		if subtotal.CurrencyID == "" {
+			subtotal.CurrencyID = order.CurrencyID
+		}
+
+		var err error
+		if total, err = total.Add(subtotal); err != nil {
+			return Money{}, err
+		}
+	}
+
+	return total, nil
+}
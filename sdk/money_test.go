@@ -0,0 +1,173 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+)
+
+func Test_Money_is_parsed_and_rounded_to_the_decimal_places_of_its_currency(t *testing.T) {
+
+	for amount, units := range map[string]int64{
+		"10":       1000,
+		"10.5":     1050,
+		"0.1":      10,
+		".25":      25,
+		"-3.07":    -307,
+		"10.255":   1026,
+		"-10.255":  -1026,
+		"10.254":   1025,
+		"1.5e2":    15000,
+		"99999.99": 9999999,
+	} {
+		money, err := sdk.ParseMoney(amount, "ARS")
+		if err != nil || money.Units != units || money.Decimals != 2 {
+			log.Printf("Error: %s was parsed as %d %v", amount, money.Units, err)
+			t.FailNow()
+		}
+	}
+
+	for amount, units := range map[string]int64{"15990": 15990, "1234.5": 1235, ".4": 0, "-7.5": -8} {
+		money, err := sdk.ParseMoney(amount, "CLP")
+		if err != nil || money.Units != units || money.Decimals != 0 {
+			log.Printf("Error: %s CLP was parsed as %d %v", amount, money.Units, err)
+			t.FailNow()
+		}
+	}
+
+	for _, amount := range []string{"", ".", "1.2.3", "abc", "1,5"} {
+		if _, err := sdk.ParseMoney(amount, "ARS"); err == nil {
+			log.Printf("Error: %q should not be parsed", amount)
+			t.FailNow()
+		}
+	}
+
+	if clp := sdk.NewMoney(15990.4, "CLP"); clp.String() != "CLP 15990" || clp.Float64() != 15990 {
+		log.Printf("Error: unexpected CLP amount %s", clp)
+		t.FailNow()
+	}
+
+	sdk.RegisterCurrencies(sdk.Currency{ID: "XTS", DecimalPlaces: 3})
+
+	if money, _ := sdk.ParseMoney("1.2345", "XTS"); money.Amount() != "1.235" {
+		log.Printf("Error: the registered decimal places were not used %s", money)
+		t.FailNow()
+	}
+}
+
+func Test_Money_arithmetic_does_not_accumulate_float_errors(t *testing.T) {
+
+	total := sdk.Money{}
+	for i := 0; i < 10; i++ {
+		total, _ = total.Add(sdk.NewMoney(0.1, "ARS"))
+	}
+
+	if total.Units != 100 || total.String() != "ARS 1.00" || total.Float64() != 1 {
+		log.Printf("Error: unexpected total %s", total)
+		t.FailNow()
+	}
+
+	if _, err := total.Add(sdk.NewMoney(1, "USD")); !errors.Is(err, sdk.ErrCurrencyMismatch) {
+		log.Printf("Error: expected ErrCurrencyMismatch obtained %v", err)
+		t.FailNow()
+	}
+
+	difference, _ := total.Sub(sdk.NewMoney(1.05, "ARS"))
+	if difference.Amount() != "-0.05" || sdk.NewMoney(19.99, "ARS").Mul(3).Amount() != "59.97" {
+		log.Printf("Error: unexpected difference %s", difference)
+		t.FailNow()
+	}
+
+	content, _ := json.Marshal(sdk.NewMoney(1234.5, "ARS"))
+	if string(content) != `{"amount":1234.50,"currency_id":"ARS"}` {
+		log.Printf("Error: unexpected JSON %s", content)
+		t.FailNow()
+	}
+
+	var decoded sdk.Money
+	if err := json.Unmarshal([]byte(`{"amount":0.30,"currency_id":"BRL"}`), &decoded); err != nil || decoded.Units != 30 || decoded.CurrencyID != "BRL" {
+		log.Printf("Error: unexpected decoded money %+v %v", decoded, err)
+		t.FailNow()
+	}
+}
+
+func Test_Order_totals_are_computed_as_money(t *testing.T) {
+
+	var order sdk.Order
+	json.Unmarshal([]byte(`{"id": 1, "total_amount": 60.3, "currency_id": "ARS", "order_items": [
+		{"item": {"id": "MLA1"}, "quantity": 3, "unit_price": 10.1, "currency_id": "ARS"},
+		{"item": {"id": "MLA2"}, "quantity": 1, "unit_price": 30}]}`), &order)
+
+	total, err := order.ItemsTotal()
+
+	if err != nil || total != order.TotalAmount || order.OrderItems[0].Subtotal().Amount() != "30.30" {
+		log.Printf("Error: unexpected items total %s %s %v", total, order.TotalAmount, err)
+		t.FailNow()
+	}
+
+	content, _ := json.Marshal(order)
+	if !strings.Contains(string(content), `"total_amount":60.30`) || !strings.Contains(string(content), `"unit_price":10.10`) {
+		log.Printf("Error: unexpected JSON %s", content)
+		t.FailNow()
+	}
+}
+
+func Test_Item_prices_are_decoded_exactly_in_their_currency(t *testing.T) {
+
+	var item sdk.Item
+	if err := json.Unmarshal([]byte(`{"id": "MLA1", "price": 1999.99, "currency_id": "ARS"}`), &item); err != nil || item.Price.Units != 199999 {
+		log.Printf("Error: unexpected price %s %v", item.Price, err)
+		t.FailNow()
+	}
+
+	if err := json.Unmarshal([]byte(`{"id": "MLC1", "price": 15990, "currency_id": "CLP"}`), &item); err != nil || item.Price.String() != "CLP 15990" {
+		log.Printf("Error: unexpected price %s %v", item.Price, err)
+		t.FailNow()
+	}
+
+	data := `{"id": "MLC2", "price": 15990, "currency_id": "CLP", "variations": [{"id": 1, "price": 15990.4, "available_quantity": 2}]}`
+	if err := json.Unmarshal([]byte(data), &item); err != nil || len(item.Variations) != 1 || item.Variations[0].Price.String() != "CLP 15990" ||
+		item.Variations[0].AvailableQuantity != 2 {
+		log.Printf("Error: unexpected variations %+v %v", item.Variations, err)
+		t.FailNow()
+	}
+
+	var payment sdk.Payment
+	data = `{"id": 1, "currency_id": "CLP", "transaction_amount": 10000, "fee_details": [{"type": "mercadopago_fee", "amount": 499.6}, {"type": "financing_fee", "amount": 1000}],
+		"transaction_details": {"net_received_amount": 8500, "total_paid_amount": 10000, "installment_amount": 3333.33}}`
+	if err := json.Unmarshal([]byte(data), &payment); err != nil || payment.TransactionAmount.String() != "CLP 10000" || payment.Details.InstallmentAmount.Amount() != "3333" {
+		log.Printf("Error: unexpected payment %+v %v", payment, err)
+		t.FailNow()
+	}
+
+	if fees, err := payment.TotalFees(); err != nil || fees.String() != "CLP 1500" {
+		log.Printf("Error: unexpected fees %s %v", fees, err)
+		t.FailNow()
+	}
+
+	content, _ := json.Marshal(sdk.Item{Title: "Item", Price: sdk.NewMoney(10.5, "ARS")})
+	if string(content) != `{"title":"Item","currency_id":"ARS","price":10.50}` {
+		log.Printf("Error: unexpected JSON %s", content)
+		t.FailNow()
+	}
+}
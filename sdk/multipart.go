@@ -0,0 +1,130 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+/*File is a file uploaded within a multipart form, i.e. a picture or an invoice*/
+type File struct {
+	//Field is the form field holding the file. It defaults to "file".
+	Field string
+	Name  string
+	//ContentType defaults to the type of the extension of Name, or application/octet-stream if it is unknown.
+	ContentType string
+	Content     io.Reader
+}
+
+/*
+PostMultipart performs a POST over the given resource with the fields and files encoded as multipart/form-data.
+The files are read into memory before the request is sent.
+*/
+func (client *Client) PostMultipart(resourcePath string, fields map[string]string, files ...File) (*http.Response, error) {
+
+	body, bodyType, err := multipartBody(fields, files)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpErrorHandler(client, resourcePath, HTTPPost{httpClient: client.httpClient, body: body, bodyType: bodyType})
+}
+
+/*postMultipart performs a multipart POST over the given resource, and decodes the response into result*/
+func (client *Client) postMultipart(resourcePath string, fields map[string]string, files []File, result interface{}) error {
+
+	resp, err := client.PostMultipart(resourcePath, fields, files...)
+	if err != nil {
+		return err
+	}
+
+	return decodeResponse(resp, result)
+}
+
+/*multipartBody encodes the fields, sorted by name, and the files, and returns the body along with its Content-Type*/
+func multipartBody(fields map[string]string, files []File) (string, string, error) {
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := writer.WriteField(name, fields[name]); err != nil {
+			return "", "", err
+		}
+	}
+
+	for _, file := range files {
+
+		if file.Content == nil {
+			return "", "", fmt.Errorf("file %q has no content", file.Name)
+		}
+
+		field := file.Field
+		if field == "" {
+			field = "file"
+		}
+
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = mime.TypeByExtension(filepath.Ext(file.Name))
+		}
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(field), escapeQuotes(file.Name)))
+		header.Set("Content-Type", contentType)
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return "", "", err
+		}
+
+		if _, err := io.Copy(part, file.Content); err != nil {
+			return "", "", err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", "", err
+	}
+
+	return body.String(), writer.FormDataContentType(), nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+/*Order is a purchase of one or more items*/
+type Order struct {
+	ID          int64       `json:"id"`
+	PackID      int64       `json:"pack_id"`
+	Status      string      `json:"status"`
+	DateCreated time.Time   `json:"date_created"`
+	DateClosed  time.Time   `json:"date_closed"`
+	TotalAmount Money       `json:"total_amount"`
+	CurrencyID  string      `json:"currency_id"`
+	Buyer       OrderUser   `json:"buyer"`
+	Seller      OrderUser   `json:"seller"`
+	OrderItems  []OrderItem `json:"order_items"`
+	Payments    []Payment   `json:"payments"`
+}
+
+/*OrderUser is the buyer or the seller of an order*/
+type OrderUser struct {
+	ID       int64  `json:"id"`
+	Nickname string `json:"nickname"`
+}
+
+/*OrderItem is an item bought within an order*/
+type OrderItem struct {
+	Item struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	} `json:"item"`
+	Quantity   int    `json:"quantity"`
+	UnitPrice  Money  `json:"unit_price"`
+	CurrencyID string `json:"currency_id"`
+}
+
+/*MarshalJSON encodes the order as the API does, with its total as a plain number along with its currency_id*/
+func (order Order) MarshalJSON() ([]byte, error) {
+
+	type plain Order
+	return json.Marshal(struct {
+		plain
+		TotalAmount json.Number `json:"total_amount"`
+	}{plain(order), json.Number(order.TotalAmount.Amount())})
+}
+
+/*UnmarshalJSON decodes the total of the order exactly, in the decimal places of its currency*/
+func (order *Order) UnmarshalJSON(data []byte) error {
+
+	type plain Order
+	var decoded struct {
+		plain
+		TotalAmount json.Number `json:"total_amount"`
+	}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	total, err := decodeAmount(decoded.TotalAmount, decoded.CurrencyID)
+	if err != nil {
+		return err
+	}
+
+	*order = Order(decoded.plain)
+	order.TotalAmount = total
+
+	return nil
+}
+
+/*MarshalJSON encodes the order item as the API does, with its unit price as a plain number*/
+func (orderItem OrderItem) MarshalJSON() ([]byte, error) {
+
+	type plain OrderItem
+	return json.Marshal(struct {
+		plain
+		UnitPrice json.Number `json:"unit_price"`
+	}{plain(orderItem), json.Number(orderItem.UnitPrice.Amount())})
+}
+
+/*UnmarshalJSON decodes the unit price of the order item exactly, in the decimal places of its currency*/
+func (orderItem *OrderItem) UnmarshalJSON(data []byte) error {
+
+	type plain OrderItem
+	var decoded struct {
+		plain
+		UnitPrice json.Number `json:"unit_price"`
+	}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	price, err := decodeAmount(decoded.UnitPrice, decoded.CurrencyID)
+	if err != nil {
+		return err
+	}
+
+	*orderItem = OrderItem(decoded.plain)
+	orderItem.UnitPrice = price
+
+	return nil
+}
+
+/*packID returns the id of the pack of the order, which is the order id when it was not bought along with others*/
+func (order *Order) packID() int64 {
+
+	if order.PackID != 0 {
+		return order.PackID
+	}
+
+	return order.ID
+}
+
+/*GetOrder returns the order with the given id*/
+func (client *Client) GetOrder(orderID int64) (*Order, error) {
+
+	order := new(Order)
+	if err := client.getJSON("/orders/"+strconv.FormatInt(orderID, 10), order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+/*
+SearchOrders returns a page of the orders sold by the user the client acts on behalf of, starting at offset.
+If status is not empty, only the orders with that status are returned, i.e. "paid".
+*/
+func (client *Client) SearchOrders(status string, offset int) ([]Order, Paging, error) {
+
+	query := statusQuery("order.status", status)
+	query.Set("seller", strconv.FormatInt(client.UserID(), 10))
+	query.Set("offset", strconv.Itoa(offset))
+
+	var result struct {
+		Results []Order `json:"results"`
+		Paging  Paging  `json:"paging"`
+	}
+
+	if err := client.getJSON("/orders/search?"+query.Encode(), &result); err != nil {
+		return nil, Paging{}, err
+	}
+
+	return result.Results, result.Paging, nil
+}
+
+/*SearchAllOrders returns every order of the user with the given status, reading one page after the other*/
+func (client *Client) SearchAllOrders(status string) ([]Order, error) {
+
+	var orders []Order
+
+	for {
+		page, paging, err := client.SearchOrders(status, len(orders))
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, page...)
+
+		if len(page) == 0 || len(orders) >= paging.Total {
+			return orders, nil
+		}
+	}
+}
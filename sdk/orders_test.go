@@ -0,0 +1,78 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"log"
+	"testing"
+
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+func Test_Orders_are_read_and_searched_by_status(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.AddOrder(sdktest.Object{
+		"id":           2000001,
+		"status":       "paid",
+		"total_amount": 30,
+		"seller":       sdktest.Object{"id": testSellerID},
+		"buyer":        sdktest.Object{"id": 1, "nickname": "TEST_BUYER"},
+		"order_items":  []sdktest.Object{{"item": sdktest.Object{"id": "MLA1", "title": "Item"}, "quantity": 3, "unit_price": 10}},
+	})
+	server.AddOrder(sdktest.Object{"id": 2000002, "status": "cancelled", "seller": sdktest.Object{"id": testSellerID}})
+
+	order, err := client.GetOrder(2000001)
+
+	if err != nil || order.Buyer.Nickname != "TEST_BUYER" || len(order.OrderItems) != 1 || order.OrderItems[0].Quantity != 3 {
+		log.Printf("Error: unexpected order %+v %v", order, err)
+		t.FailNow()
+	}
+
+	orders, paging, err := client.SearchOrders("paid", 0)
+
+	if err != nil || len(orders) != 1 || orders[0].ID != 2000001 || paging.Total != 1 {
+		log.Printf("Error: unexpected orders %+v %v", orders, err)
+		t.FailNow()
+	}
+}
+
+func Test_Every_page_of_the_orders_search_is_read(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	for i := 1; i <= 60; i++ {
+		server.AddOrder(sdktest.Object{"id": 3000000 + i, "status": "paid", "seller": sdktest.Object{"id": testSellerID}})
+	}
+
+	orders, paging, err := client.SearchOrders("paid", 50)
+
+	if err != nil || len(orders) != 10 || orders[0].ID != 3000051 || paging.Total != 60 {
+		log.Printf("Error: unexpected page %d %+v %v", len(orders), paging, err)
+		t.FailNow()
+	}
+
+	orders, err = client.SearchAllOrders("paid")
+
+	if err != nil || len(orders) != 60 || orders[59].ID != 3000060 {
+		log.Printf("Error: expected every order obtained %d %v", len(orders), err)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package otelmeli provides an OpenTelemetry sdk.Instrumentation.
+
+Every API call performed by the Client is traced as a span named after its method and resource template,
+i.e. "GET /items/{id}", and a token refresh is traced as a child span of the call which triggered it. The span holds the number of
+failed attempts a retried call follows, as the BulkUpdater ones, in its meli.retries attribute.
+The errors recorded on the spans are redacted, as the logs are, so no token or secret is exported.
+The following metrics are recorded as well:
+
+	meli.client.duration         histogram of the API calls latency, in seconds
+	meli.client.errors           count of calls that failed or returned a status code >= 400
+	meli.client.token.refreshes  count of token refreshes, by result
+	meli.client.ratelimit.wait   histogram of the time waited because of rate limiting, in seconds
+
+Usage:
+
+	client, err := sdk.MeliClient(sdk.MeliConfig{
+		...
+		Instrumentation: otelmeli.New(),
+	})
+*/
+package otelmeli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/mercadolibre/golang-sdk/sdk/otelmeli"
+
+/*Option allows you to change the providers used by the Instrumentation*/
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+/*WithTracerProvider sets the TracerProvider. The global one is used by default.*/
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+/*WithMeterProvider sets the MeterProvider. The global one is used by default.*/
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+/*Instrumentation implements sdk.Instrumentation and sdk.RateLimitRecorder. Its call recorders implement sdk.RetryRecorder.*/
+type Instrumentation struct {
+	tracer        trace.Tracer
+	duration      metric.Float64Histogram
+	errors        metric.Int64Counter
+	refreshes     metric.Int64Counter
+	rateLimitWait metric.Float64Histogram
+}
+
+/*New returns an Instrumentation ready to be set in sdk.MeliConfig*/
+func New(options ...Option) *Instrumentation {
+
+	c := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	meter := c.meterProvider.Meter(instrumentationName)
+
+	instrumentation := &Instrumentation{tracer: c.tracerProvider.Tracer(instrumentationName)}
+
+	//Instrument creation only fails on invalid names, which are constants here.
+	instrumentation.duration, _ = meter.Float64Histogram("meli.client.duration",
+		metric.WithDescription("Duration of the MercadoLibre API calls"), metric.WithUnit("s"))
+	instrumentation.errors, _ = meter.Int64Counter("meli.client.errors",
+		metric.WithDescription("MercadoLibre API calls that failed or returned an error status code"))
+	instrumentation.refreshes, _ = meter.Int64Counter("meli.client.token.refreshes",
+		metric.WithDescription("Access token refreshes"))
+	instrumentation.rateLimitWait, _ = meter.Float64Histogram("meli.client.ratelimit.wait",
+		metric.WithDescription("Time waited because of rate limiting"), metric.WithUnit("s"))
+
+	return instrumentation
+}
+
+func (instrumentation *Instrumentation) StartCall(method string, resourcePath string) sdk.CallRecorder {
+
+	resource := sdk.ResourceTemplate(resourcePath)
+
+	ctx, span := instrumentation.tracer.Start(context.Background(), method+" "+resource,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", method),
+			attribute.String("meli.resource", resource),
+		))
+
+	return &callRecorder{
+		instrumentation: instrumentation,
+		ctx:             ctx,
+		span:            span,
+		start:           time.Now(),
+		attributes:      []attribute.KeyValue{attribute.String("http.request.method", method), attribute.String("meli.resource", resource)},
+	}
+}
+
+func (instrumentation *Instrumentation) RateLimitWaited(resourcePath string, wait time.Duration) {
+
+	instrumentation.rateLimitWait.Record(context.Background(), wait.Seconds(),
+		metric.WithAttributes(attribute.String("meli.resource", sdk.ResourceTemplate(resourcePath))))
+}
+
+type callRecorder struct {
+	instrumentation *Instrumentation
+	ctx             context.Context
+	span            trace.Span
+	start           time.Time
+	attributes      []attribute.KeyValue
+	refreshes       int
+	retries         int
+}
+
+func (recorder *callRecorder) TokenRefreshed(start time.Time, err error) {
+
+	recorder.refreshes++
+
+	_, span := recorder.instrumentation.tracer.Start(recorder.ctx, "meli.token.refresh", trace.WithTimestamp(start))
+
+	result := "success"
+	if err != nil {
+		result = "failure"
+		recordError(span, err)
+	}
+
+	span.End()
+
+	recorder.instrumentation.refreshes.Add(recorder.ctx, 1, metric.WithAttributes(attribute.String("result", result)))
+}
+
+func (recorder *callRecorder) Retried(retries int) {
+	recorder.retries = retries
+}
+
+func (recorder *callRecorder) End(resp *http.Response, err error) {
+
+	attributes := recorder.attributes
+
+	if resp != nil {
+		attributes = append(attributes, attribute.Int("http.response.status_code", resp.StatusCode))
+	}
+
+	recorder.span.SetAttributes(attributes...)
+	recorder.span.SetAttributes(attribute.Int("meli.token.refreshes", recorder.refreshes), attribute.Int("meli.retries", recorder.retries))
+
+	failed := false
+
+	if err != nil {
+		failed = true
+		recordError(recorder.span, err)
+	} else if resp.StatusCode >= http.StatusBadRequest {
+		failed = true
+		recorder.span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+
+	recorder.span.End()
+
+	measurement := metric.WithAttributes(attributes...)
+	recorder.instrumentation.duration.Record(recorder.ctx, time.Since(recorder.start).Seconds(), measurement)
+
+	if failed {
+		recorder.instrumentation.errors.Add(recorder.ctx, 1, measurement)
+	}
+}
+
+/*
+recordError records the given error on the span, redacted, as the *url.Error returned by the http client
+holds the whole URL of the call, tokens and client secret included.
+*/
+func recordError(span trace.Span, err error) {
+
+	message := sdk.Redact(err.Error())
+
+	span.RecordError(errors.New(message))
+	span.SetStatus(codes.Error, message)
+}
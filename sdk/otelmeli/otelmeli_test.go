@@ -0,0 +1,290 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otelmeli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_API_calls_are_traced_and_measured(t *testing.T) {
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	instrumentation := New(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+
+	client, err := sdk.MeliClient(sdk.MeliConfig{
+		ClientID:        123456,
+		UserCode:        "otel code",
+		Secret:          "client secret",
+		CallBackURL:     "http://www.example.com",
+		HTTPClient:      expiringTokenHTTPClient{},
+		Instrumentation: instrumentation,
+	})
+
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	if _, err := client.Get("/items/MLA123456"); err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	ended := spans.Ended()
+
+	if len(ended) != 2 || ended[0].Name() != "meli.token.refresh" || ended[1].Name() != "GET /items/{id}" {
+		log.Printf("Error: unexpected spans %v", ended)
+		t.FailNow()
+	}
+
+	if ended[0].Parent().SpanID() != ended[1].SpanContext().SpanID() {
+		log.Printf("Error: token refresh should be a child of the API call span")
+		t.FailNow()
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	names := map[string]bool{}
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			names[m.Name] = true
+		}
+	}
+
+	if !names["meli.client.duration"] || !names["meli.client.token.refreshes"] {
+		log.Printf("Error: metrics were not recorded %v", names)
+		t.FailNow()
+	}
+}
+
+func Test_Retries_are_set_on_the_span_of_the_call(t *testing.T) {
+
+	spans := tracetest.NewSpanRecorder()
+	instrumentation := New(WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))))
+
+	client, err := sdk.MeliClient(sdk.MeliConfig{
+		ClientID:        123456,
+		UserCode:        "otel retry code",
+		Secret:          "client secret",
+		CallBackURL:     "http://www.example.com",
+		HTTPClient:      &unavailableHTTPClient{},
+		Instrumentation: instrumentation,
+	})
+
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	updater := sdk.NewBulkUpdater(client, sdk.BulkConfig{MaxRetries: 1, RetryBackoff: time.Millisecond})
+	report := updater.UpdateAll(context.Background(), []sdk.ItemPatch{{ItemID: "MLA123", Changes: map[string]interface{}{"price": 10}}})
+
+	if len(report) != 1 || report[0].Status != sdk.BulkUpdated {
+		log.Printf("Error: the update was not retried %v", report)
+		t.FailNow()
+	}
+
+	ended := spans.Ended()
+	call := ended[len(ended)-1]
+
+	for _, attr := range call.Attributes() {
+		if attr.Key == "meli.retries" && attr.Value.AsInt64() == 1 {
+			return
+		}
+	}
+
+	log.Printf("Error: retries were not set on the span %v", call.Attributes())
+	t.FailNow()
+}
+
+func Test_Errors_are_recorded_on_the_spans_without_secrets(t *testing.T) {
+
+	spans := tracetest.NewSpanRecorder()
+	instrumentation := New(WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))))
+
+	client, err := sdk.MeliClient(sdk.MeliConfig{
+		ClientID:        123456,
+		UserCode:        "otel failing code",
+		Secret:          "SECRET_CLIENT",
+		CallBackURL:     "http://www.example.com",
+		HTTPClient:      &failingHTTPClient{},
+		Instrumentation: instrumentation,
+	})
+
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	//the first call fails once its token is refreshed, and the second one fails refreshing it
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get("/users/me"); err == nil {
+			log.Printf("Error: the call should have failed")
+			t.FailNow()
+		}
+	}
+
+	recorded := 0
+
+	for _, span := range spans.Ended() {
+
+		texts := []string{span.Status().Description}
+		for _, event := range span.Events() {
+			for _, attr := range event.Attributes {
+				texts = append(texts, attr.Value.Emit())
+			}
+		}
+
+		for _, text := range texts {
+			for _, secret := range []string{"SECRET_CLIENT", "SECRET_ACCESS", "SECRET_REFRESH"} {
+				if strings.Contains(text, secret) {
+					log.Printf("Error: %s was recorded on the span %s: %s", secret, span.Name(), text)
+					t.FailNow()
+				}
+			}
+		}
+
+		if span.Status().Description != "" {
+			recorded++
+		}
+	}
+
+	if recorded != 3 {
+		log.Printf("Error: expected the two calls and the refresh to record their errors, %d did", recorded)
+		t.FailNow()
+	}
+}
+
+func Test_Public_clients_are_instrumented(t *testing.T) {
+
+	spans := tracetest.NewSpanRecorder()
+	instrumentation := New(WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))))
+
+	client, err := sdk.MeliClient(sdk.MeliConfig{HTTPClient: expiringTokenHTTPClient{}, Instrumentation: instrumentation})
+
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	if _, err := client.Get("/sites/MLA"); err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	if ended := spans.Ended(); len(ended) != 1 || ended[0].Name() != "GET /sites/MLA" {
+		log.Printf("Error: unexpected spans %v", ended)
+		t.FailNow()
+	}
+}
+
+/*expiringTokenHTTPClient returns tokens which are already expired, so every call refreshes them.*/
+type expiringTokenHTTPClient struct{}
+
+func (expiringTokenHTTPClient) Get(url string) (*http.Response, error) {
+	return response(http.StatusOK, "{}"), nil
+}
+
+func (expiringTokenHTTPClient) Post(url string, bodyType string, body io.Reader) (*http.Response, error) {
+
+	if strings.Contains(url, "/oauth/token") {
+		return response(http.StatusOK, "{\"access_token\":\"token\",\"expires_in\":0,\"refresh_token\":\"refresh\"}"), nil
+	}
+
+	return response(http.StatusCreated, "{}"), nil
+}
+
+func (expiringTokenHTTPClient) Put(url string, body io.Reader) (*http.Response, error) {
+	return response(http.StatusOK, "{}"), nil
+}
+
+func (expiringTokenHTTPClient) Delete(url string, body io.Reader) (*http.Response, error) {
+	return response(http.StatusOK, "{}"), nil
+}
+
+func response(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: ioutil.NopCloser(bytes.NewReader([]byte(body)))}
+}
+
+/*unavailableHTTPClient fails the first update it receives, as the API does when it is unavailable.*/
+type unavailableHTTPClient struct {
+	expiringTokenHTTPClient
+	failed bool
+}
+
+func (httpClient *unavailableHTTPClient) Put(url string, body io.Reader) (*http.Response, error) {
+
+	if !httpClient.failed {
+		httpClient.failed = true
+		return response(http.StatusServiceUnavailable, "{}"), nil
+	}
+
+	return response(http.StatusOK, "{}"), nil
+}
+
+/*failingHTTPClient authorizes the code and refreshes the token once, and then fails as a broken connection does.*/
+type failingHTTPClient struct {
+	posts int
+}
+
+func (httpClient *failingHTTPClient) Get(rawURL string) (*http.Response, error) {
+	return nil, &url.Error{Op: "Get", URL: rawURL, Err: errors.New("connection reset by peer")}
+}
+
+func (httpClient *failingHTTPClient) Post(rawURL string, bodyType string, body io.Reader) (*http.Response, error) {
+
+	httpClient.posts++
+
+	if httpClient.posts > 2 {
+		return nil, &url.Error{Op: "Post", URL: rawURL, Err: errors.New("connection reset by peer")}
+	}
+
+	return response(http.StatusOK, "{\"access_token\":\"SECRET_ACCESS\",\"expires_in\":0,\"refresh_token\":\"SECRET_REFRESH\"}"), nil
+}
+
+func (httpClient *failingHTTPClient) Put(rawURL string, body io.Reader) (*http.Response, error) {
+	return httpClient.Get(rawURL)
+}
+
+func (httpClient *failingHTTPClient) Delete(rawURL string, body io.Reader) (*http.Response, error) {
+	return httpClient.Get(rawURL)
+}
@@ -0,0 +1,242 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+/*maxPaymentFetches is how many payments are fetched at the same time when enriching orders*/
+const maxPaymentFetches = 8
+
+/*
+Payment is a Mercado Pago payment, i.e. the one an order was paid with.
+Its amounts, fees included, are in its currency.
+*/
+type Payment struct {
+	ID                int64              `json:"id"`
+	OrderID           int64              `json:"order_id,omitempty"`
+	Status            string             `json:"status"`
+	StatusDetail      string             `json:"status_detail"`
+	PaymentMethodID   string             `json:"payment_method_id"`
+	PaymentTypeID     string             `json:"payment_type_id"`
+	Installments      int                `json:"installments"`
+	TransactionAmount Money              `json:"transaction_amount"`
+	CurrencyID        string             `json:"currency_id"`
+	FeeDetails        []PaymentFee       `json:"fee_details,omitempty"`
+	Details           PaymentTransaction `json:"transaction_details"`
+	DateCreated       *time.Time         `json:"date_created,omitempty"`
+	DateApproved      *time.Time         `json:"date_approved,omitempty"`
+}
+
+/*PaymentFee is a fee charged on a payment, i.e. the mercadopago_fee, and who pays it*/
+type PaymentFee struct {
+	Type     string `json:"type"`
+	Amount   Money  `json:"amount"`
+	FeePayer string `json:"fee_payer"`
+}
+
+/*PaymentTransaction is what the payer paid, in total and per installment, and what the collector received*/
+type PaymentTransaction struct {
+	NetReceivedAmount Money `json:"net_received_amount"`
+	TotalPaidAmount   Money `json:"total_paid_amount"`
+	InstallmentAmount Money `json:"installment_amount"`
+}
+
+/*paymentFeeAmount is a fee as the API encodes it, with its amount as a plain number*/
+type paymentFeeAmount struct {
+	Type     string      `json:"type"`
+	Amount   json.Number `json:"amount"`
+	FeePayer string      `json:"fee_payer"`
+}
+
+/*paymentTransactionAmounts are the transaction details as the API encodes them, with plain numbers*/
+type paymentTransactionAmounts struct {
+	NetReceivedAmount json.Number `json:"net_received_amount"`
+	TotalPaidAmount   json.Number `json:"total_paid_amount"`
+	InstallmentAmount json.Number `json:"installment_amount"`
+}
+
+/*MarshalJSON encodes the payment as the API does, with its amounts as plain numbers along with its currency_id*/
+func (payment Payment) MarshalJSON() ([]byte, error) {
+
+	type plain Payment
+	encoded := struct {
+		plain
+		TransactionAmount json.Number               `json:"transaction_amount"`
+		FeeDetails        []paymentFeeAmount        `json:"fee_details,omitempty"`
+		Details           paymentTransactionAmounts `json:"transaction_details"`
+	}{plain: plain(payment), TransactionAmount: json.Number(payment.TransactionAmount.Amount())}
+
+	for _, fee := range payment.FeeDetails {
+		encoded.FeeDetails = append(encoded.FeeDetails, paymentFeeAmount{fee.Type, json.Number(fee.Amount.Amount()), fee.FeePayer})
+	}
+
+	encoded.Details = paymentTransactionAmounts{
+		NetReceivedAmount: json.Number(payment.Details.NetReceivedAmount.Amount()),
+		TotalPaidAmount:   json.Number(payment.Details.TotalPaidAmount.Amount()),
+		InstallmentAmount: json.Number(payment.Details.InstallmentAmount.Amount()),
+	}
+
+	return json.Marshal(encoded)
+}
+
+/*UnmarshalJSON decodes the amounts of the payment and its fees exactly, in the decimal places of its currency*/
+func (payment *Payment) UnmarshalJSON(data []byte) error {
+
+	type plain Payment
+	var decoded struct {
+		plain
+		TransactionAmount json.Number               `json:"transaction_amount"`
+		FeeDetails        []paymentFeeAmount        `json:"fee_details"`
+		Details           paymentTransactionAmounts `json:"transaction_details"`
+	}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	currencyID := decoded.CurrencyID
+
+	*payment = Payment(decoded.plain)
+
+	var err error
+
+	if payment.TransactionAmount, err = decodeAmount(decoded.TransactionAmount, currencyID); err != nil {
+		return err
+	}
+
+	for _, decodedFee := range decoded.FeeDetails {
+
+		fee := PaymentFee{Type: decodedFee.Type, FeePayer: decodedFee.FeePayer}
+		if fee.Amount, err = decodeAmount(decodedFee.Amount, currencyID); err != nil {
+			return err
+		}
+
+		payment.FeeDetails = append(payment.FeeDetails, fee)
+	}
+
+	if payment.Details.NetReceivedAmount, err = decodeAmount(decoded.Details.NetReceivedAmount, currencyID); err != nil {
+		return err
+	}
+
+	if payment.Details.TotalPaidAmount, err = decodeAmount(decoded.Details.TotalPaidAmount, currencyID); err != nil {
+		return err
+	}
+
+	payment.Details.InstallmentAmount, err = decodeAmount(decoded.Details.InstallmentAmount, currencyID)
+
+	return err
+}
+
+/*TotalFees returns the sum of the fees charged on the payment, in its currency*/
+func (payment Payment) TotalFees() (Money, error) {
+
+	total := Money{Decimals: DecimalPlaces(payment.CurrencyID), CurrencyID: payment.CurrencyID}
+
+	for _, fee := range payment.FeeDetails {
+
+		var err error
+		if total, err = total.Add(fee.Amount); err != nil {
+			return Money{}, err
+		}
+	}
+
+	return total, nil
+}
+
+/*GetPayment returns the payment with the given id*/
+func (client *Client) GetPayment(paymentID int64) (*Payment, error) {
+
+	payment := new(Payment)
+	if err := client.getJSON("/v1/payments/"+strconv.FormatInt(paymentID, 10), payment); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+/*EnrichOrder replaces the payments of an order, which only hold a summary, with their details. See EnrichOrders.*/
+func (client *Client) EnrichOrder(order *Order) error {
+
+	return client.enrichPayments([]*Order{order})
+}
+
+/*
+EnrichOrders replaces the payments of the orders, which only hold a summary, with their details.
+Payments are fetched concurrently. If some cannot be fetched, they are kept as they were and the first error is returned.
+*/
+func (client *Client) EnrichOrders(orders []Order) error {
+
+	pointers := make([]*Order, len(orders))
+	for i := range orders {
+		pointers[i] = &orders[i]
+	}
+
+	return client.enrichPayments(pointers)
+}
+
+func (client *Client) enrichPayments(orders []*Order) error {
+
+	var payments []*Payment
+	for _, order := range orders {
+		for i := range order.Payments {
+			payments = append(payments, &order.Payments[i])
+		}
+	}
+
+	errs := make([]error, len(payments))
+	slots := make(chan struct{}, maxPaymentFetches)
+
+	var fetches sync.WaitGroup
+	fetches.Add(len(payments))
+
+	for i, payment := range payments {
+
+		slots <- struct{}{}
+
+		go func(i int, payment *Payment) {
+			defer func() { <-slots; fetches.Done() }()
+
+			details, err := client.GetPayment(payment.ID)
+			if err != nil {
+				errs[i] = fmt.Errorf("payment %d: %w", payment.ID, err)
+				return
+			}
+
+			if details.OrderID == 0 {
+				details.OrderID = payment.OrderID
+			}
+
+			*payment = *details
+		}(i, payment)
+	}
+
+	fetches.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
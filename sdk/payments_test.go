@@ -0,0 +1,120 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+func addPayment(server *sdktest.Server, id int64, orderID int64, installments int) {
+	server.AddPayment(sdktest.Object{
+		"id":                 id,
+		"order_id":           orderID,
+		"collector_id":       testSellerID,
+		"status":             "approved",
+		"status_detail":      "accredited",
+		"installments":       installments,
+		"transaction_amount": 100,
+		"currency_id":        "ARS",
+		"fee_details": []sdktest.Object{
+			{"type": "mercadopago_fee", "amount": 4.99, "fee_payer": "collector"},
+			{"type": "financing_fee", "amount": 10.01, "fee_payer": "collector"},
+		},
+		"transaction_details": sdktest.Object{"net_received_amount": 85, "total_paid_amount": 100, "installment_amount": 100 / float64(installments)},
+	})
+}
+
+func Test_Payment_is_read(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	addPayment(server, 500, 2000001, 3)
+	server.AddPayment(sdktest.Object{"id": 501, "collector_id": 99})
+
+	payment, err := client.GetPayment(500)
+
+	if err != nil || payment.Status != "approved" || payment.StatusDetail != "accredited" || payment.Installments != 3 ||
+		payment.TransactionAmount.String() != "ARS 100.00" || payment.Details.NetReceivedAmount.Amount() != "85.00" ||
+		payment.Details.InstallmentAmount.Amount() != "33.33" {
+		log.Printf("Error: unexpected payment %+v %v", payment, err)
+		t.FailNow()
+	}
+
+	if fees, err := payment.TotalFees(); err != nil || fees.String() != "ARS 15.00" {
+		log.Printf("Error: unexpected fees %s %v", fees, err)
+		t.FailNow()
+	}
+
+	var apiErr *sdk.APIError
+	if _, err := client.GetPayment(501); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		log.Printf("Error: expected a forbidden error obtained %v", err)
+		t.FailNow()
+	}
+}
+
+func Test_Orders_are_enriched_with_their_payments(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	for i := int64(1); i <= 10; i++ {
+		server.AddOrder(sdktest.Object{"id": 2000000 + i, "status": "paid", "seller": sdktest.Object{"id": testSellerID},
+			"payments": []sdktest.Object{{"id": 500 + i, "status": "approved"}, {"id": 600 + i, "status": "approved"}}})
+		addPayment(server, 500+i, 2000000+i, 1)
+		addPayment(server, 600+i, 2000000+i, 6)
+	}
+
+	orders, _, err := client.SearchOrders("paid", 0)
+	if err != nil || len(orders) != 10 {
+		log.Printf("Error: unexpected orders %+v %v", orders, err)
+		t.FailNow()
+	}
+
+	if err := client.EnrichOrders(orders); err != nil {
+		log.Printf("Error: orders were not enriched %v", err)
+		t.FailNow()
+	}
+
+	for _, order := range orders {
+		if len(order.Payments) != 2 || order.Payments[0].ID != order.ID-2000000+500 || order.Payments[0].StatusDetail != "accredited" ||
+			order.Payments[1].Installments != 6 || order.Payments[1].OrderID != order.ID {
+			log.Printf("Error: unexpected payments %+v", order.Payments)
+			t.FailNow()
+		}
+	}
+
+	order, _ := client.GetOrder(2000001)
+	order.Payments = append(order.Payments, sdk.Payment{ID: 999, Status: "approved"})
+
+	var apiErr *sdk.APIError
+	if err := client.EnrichOrder(order); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		log.Printf("Error: expected a not found error obtained %v", err)
+		t.FailNow()
+	}
+
+	if order.Payments[0].StatusDetail != "accredited" || order.Payments[2].StatusDetail != "" {
+		log.Printf("Error: the payments which could be fetched should have been enriched %+v", order.Payments)
+		t.FailNow()
+	}
+}
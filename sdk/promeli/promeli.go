@@ -0,0 +1,161 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package promeli provides a prometheus.Collector which is also an sdk.Instrumentation.
+
+The following metrics are exposed:
+
+	meli_client_requests_total                  counter by method, resource and status code class (2xx, 4xx, error...)
+	meli_client_request_duration_seconds        histogram by method and resource
+	meli_client_token_refreshes_total           counter by result (success, failure)
+	meli_client_ratelimit_wait_seconds_total    counter by resource
+	meli_client_active_clients                  gauge of the authorized clients kept by the client managers
+
+Usage:
+
+	manager := sdk.NewClientManager(sdk.ManagerConfig{})
+	collector := promeli.NewCollector(manager)
+	prometheus.MustRegister(collector)
+
+	client, err := manager.Client(sdk.MeliConfig{
+		...
+		Instrumentation: collector,
+	})
+*/
+package promeli
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "meli_client"
+
+/*Collector implements prometheus.Collector, sdk.Instrumentation and sdk.RateLimitRecorder*/
+type Collector struct {
+	managers      []*sdk.ClientManager
+	requests      *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+	refreshes     *prometheus.CounterVec
+	rateLimitWait *prometheus.CounterVec
+	activeClients *prometheus.Desc
+}
+
+/*
+NewCollector returns a Collector which still has to be registered.
+The active clients are counted across the given managers.
+*/
+func NewCollector(manager *sdk.ClientManager, others ...*sdk.ClientManager) *Collector {
+
+	return &Collector{
+		managers: append([]*sdk.ClientManager{manager}, others...),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "MercadoLibre API calls by method, resource and status code class.",
+		}, []string{"method", "resource", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the MercadoLibre API calls.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "resource"}),
+		refreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "token_refreshes_total",
+			Help:      "Access token refreshes by result.",
+		}, []string{"result"}),
+		rateLimitWait: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "ratelimit_wait_seconds_total",
+			Help:      "Time waited because of rate limiting.",
+		}, []string{"resource"}),
+		activeClients: prometheus.NewDesc(namespace+"_active_clients",
+			"Authorized clients kept by the client managers.", nil, nil),
+	}
+}
+
+func (collector *Collector) Describe(ch chan<- *prometheus.Desc) {
+	collector.requests.Describe(ch)
+	collector.duration.Describe(ch)
+	collector.refreshes.Describe(ch)
+	collector.rateLimitWait.Describe(ch)
+	ch <- collector.activeClients
+}
+
+func (collector *Collector) Collect(ch chan<- prometheus.Metric) {
+	collector.requests.Collect(ch)
+	collector.duration.Collect(ch)
+	collector.refreshes.Collect(ch)
+	collector.rateLimitWait.Collect(ch)
+
+	active := 0
+	for _, manager := range collector.managers {
+		active += manager.Len()
+	}
+	ch <- prometheus.MustNewConstMetric(collector.activeClients, prometheus.GaugeValue, float64(active))
+}
+
+func (collector *Collector) StartCall(method string, resourcePath string) sdk.CallRecorder {
+	return &callRecorder{
+		collector: collector,
+		method:    method,
+		resource:  sdk.ResourceTemplate(resourcePath),
+		start:     time.Now(),
+	}
+}
+
+func (collector *Collector) RateLimitWaited(resourcePath string, wait time.Duration) {
+	collector.rateLimitWait.WithLabelValues(sdk.ResourceTemplate(resourcePath)).Add(wait.Seconds())
+}
+
+type callRecorder struct {
+	collector *Collector
+	method    string
+	resource  string
+	start     time.Time
+}
+
+func (recorder *callRecorder) TokenRefreshed(start time.Time, err error) {
+
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+
+	recorder.collector.refreshes.WithLabelValues(result).Inc()
+}
+
+func (recorder *callRecorder) End(resp *http.Response, err error) {
+
+	recorder.collector.duration.WithLabelValues(recorder.method, recorder.resource).Observe(time.Since(recorder.start).Seconds())
+	recorder.collector.requests.WithLabelValues(recorder.method, recorder.resource, codeClass(resp, err)).Inc()
+}
+
+/*codeClass returns 2xx, 3xx, 4xx or 5xx for the given response, or error if there is no response at all*/
+func codeClass(resp *http.Response, err error) string {
+
+	if err != nil || resp == nil {
+		return "error"
+	}
+
+	return strconv.Itoa(resp.StatusCode/100) + "xx"
+}
@@ -0,0 +1,102 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promeli
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func Test_API_calls_are_counted_by_resource_and_code_class(t *testing.T) {
+
+	manager := sdk.NewClientManager(sdk.ManagerConfig{})
+	collector := NewCollector(manager)
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(collector)
+
+	client, err := manager.Client(sdk.MeliConfig{
+		ClientID:        123456,
+		UserCode:        "prometheus code",
+		Secret:          "client secret",
+		CallBackURL:     "http://www.example.com",
+		HTTPClient:      expiringTokenHTTPClient{},
+		Instrumentation: collector,
+	})
+
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	client.Get("/items/MLA123")
+	client.Get("/items/MLA456")
+	client.Delete("/items/MLA456")
+
+	if count := testutil.ToFloat64(collector.requests.WithLabelValues(http.MethodGet, "/items/{id}", "2xx")); count != 2 {
+		log.Printf("Error: expected 2 GET calls obtained %f", count)
+		t.FailNow()
+	}
+
+	if count := testutil.ToFloat64(collector.requests.WithLabelValues(http.MethodDelete, "/items/{id}", "4xx")); count != 1 {
+		log.Printf("Error: expected 1 DELETE call obtained %f", count)
+		t.FailNow()
+	}
+
+	if count := testutil.ToFloat64(collector.refreshes.WithLabelValues("success")); count != 3 {
+		log.Printf("Error: expected 3 token refreshes obtained %f", count)
+		t.FailNow()
+	}
+
+	expected := "# HELP meli_client_active_clients Authorized clients kept by the client managers.\n# TYPE meli_client_active_clients gauge\nmeli_client_active_clients 1\n"
+
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "meli_client_active_clients"); err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+}
+
+/*expiringTokenHTTPClient returns tokens which are already expired, so every call refreshes them.*/
+type expiringTokenHTTPClient struct{}
+
+func (expiringTokenHTTPClient) Get(url string) (*http.Response, error) {
+	return response(http.StatusOK, "{}"), nil
+}
+
+func (expiringTokenHTTPClient) Post(url string, bodyType string, body io.Reader) (*http.Response, error) {
+	return response(http.StatusOK, "{\"access_token\":\"token\",\"expires_in\":0,\"refresh_token\":\"refresh\"}"), nil
+}
+
+func (expiringTokenHTTPClient) Put(url string, body io.Reader) (*http.Response, error) {
+	return response(http.StatusOK, "{}"), nil
+}
+
+func (expiringTokenHTTPClient) Delete(url string, body io.Reader) (*http.Response, error) {
+	return response(http.StatusNotFound, "{}"), nil
+}
+
+func response(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: ioutil.NopCloser(bytes.NewReader([]byte(body)))}
+}
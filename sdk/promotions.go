@@ -0,0 +1,215 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+/*PromotionType is the kind of a seller promotion*/
+type PromotionType string
+
+const (
+	PromotionDeal                PromotionType = "DEAL"
+	PromotionMarketplaceCampaign PromotionType = "MARKETPLACE_CAMPAIGN"
+	PromotionDealOfTheDay        PromotionType = "DOD"
+	PromotionLightning           PromotionType = "LIGHTNING"
+	PromotionPriceDiscount       PromotionType = "PRICE_DISCOUNT"
+	PromotionSellerCampaign      PromotionType = "SELLER_CAMPAIGN"
+	PromotionVolume              PromotionType = "VOLUME"
+)
+
+/*PromotionStatus is the state of a promotion, or of an item within a promotion*/
+type PromotionStatus string
+
+const (
+	//PromotionCandidate items can be added to the promotion
+	PromotionCandidate PromotionStatus = "candidate"
+	//PromotionPending promotions, or items, are waiting for the promotion to start
+	PromotionPending  PromotionStatus = "pending"
+	PromotionStarted  PromotionStatus = "started"
+	PromotionFinished PromotionStatus = "finished"
+)
+
+/*Promotion is a campaign items can be offered in at a discount price*/
+type Promotion struct {
+	ID           string          `json:"id"`
+	Type         PromotionType   `json:"type"`
+	Status       PromotionStatus `json:"status"`
+	Name         string          `json:"name"`
+	StartDate    *time.Time      `json:"start_date,omitempty"`
+	FinishDate   *time.Time      `json:"finish_date,omitempty"`
+	DeadlineDate *time.Time      `json:"deadline_date,omitempty"`
+}
+
+/*
+PromotionItem is an item of a promotion, either a candidate one or one already offered in it.
+The discount price has to be within the minimum and maximum ones, when they are given.
+*/
+type PromotionItem struct {
+	ID                       string          `json:"id"`
+	Status                   PromotionStatus `json:"status"`
+	Price                    float64         `json:"price"`
+	OriginalPrice            float64         `json:"original_price"`
+	MinDiscountedPrice       float64         `json:"min_discounted_price"`
+	MaxDiscountedPrice       float64         `json:"max_discounted_price"`
+	SuggestedDiscountedPrice float64         `json:"suggested_discounted_price"`
+}
+
+/*AcceptsPrice tells whether the item can be offered in the promotion at the given price*/
+func (item PromotionItem) AcceptsPrice(price float64) bool {
+
+	return price > 0 && (item.MinDiscountedPrice == 0 || price >= item.MinDiscountedPrice) &&
+		(item.MaxDiscountedPrice == 0 || price <= item.MaxDiscountedPrice)
+}
+
+/*ItemPromotion is a promotion an item is a candidate of, or offered in*/
+type ItemPromotion struct {
+	ID            string          `json:"id"`
+	Type          PromotionType   `json:"type"`
+	Status        PromotionStatus `json:"status"`
+	Name          string          `json:"name"`
+	Price         float64         `json:"price"`
+	OriginalPrice float64         `json:"original_price"`
+	StartDate     *time.Time      `json:"start_date,omitempty"`
+	FinishDate    *time.Time      `json:"finish_date,omitempty"`
+}
+
+/*
+PromotionOffer offers an item in a promotion at a discount price.
+PromotionID is not needed for PromotionPriceDiscount, which takes the dates instead.
+*/
+type PromotionOffer struct {
+	PromotionID string        `json:"promotion_id,omitempty"`
+	Type        PromotionType `json:"promotion_type"`
+	DealPrice   float64       `json:"deal_price"`
+	StartDate   *time.Time    `json:"start_date,omitempty"`
+	FinishDate  *time.Time    `json:"finish_date,omitempty"`
+}
+
+/*GetPromotions returns the promotions available to the user the client acts on behalf of*/
+func (client *Client) GetPromotions() ([]Promotion, error) {
+
+	var result struct {
+		Results []Promotion `json:"results"`
+	}
+
+	if err := client.getJSON("/seller-promotions/users/"+strconv.FormatInt(client.UserID(), 10)+"?app_version=v2", &result); err != nil {
+		return nil, err
+	}
+
+	return result.Results, nil
+}
+
+/*GetPromotion returns a promotion of the given type*/
+func (client *Client) GetPromotion(promotionID string, promotionType PromotionType) (*Promotion, error) {
+
+	promotion := new(Promotion)
+	if err := client.getJSON(promotionPath(promotionID, "", promotionType, ""), promotion); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+/*
+GetPromotionItems returns a page of the items of a promotion, starting at offset. If status is not empty, only the items
+with that status are returned, i.e. PromotionCandidate for the ones which can be added.
+*/
+func (client *Client) GetPromotionItems(promotionID string, promotionType PromotionType, status PromotionStatus, offset int) ([]PromotionItem, Paging, error) {
+
+	var result struct {
+		Results []PromotionItem `json:"results"`
+		Paging  Paging          `json:"paging"`
+	}
+
+	resource := promotionPath(promotionID, "/items", promotionType, status) + "&offset=" + strconv.Itoa(offset)
+	if err := client.getJSON(resource, &result); err != nil {
+		return nil, Paging{}, err
+	}
+
+	return result.Results, result.Paging, nil
+}
+
+/*GetAllPromotionItems returns every item of a promotion with the given status, reading one page after the other*/
+func (client *Client) GetAllPromotionItems(promotionID string, promotionType PromotionType, status PromotionStatus) ([]PromotionItem, error) {
+
+	var items []PromotionItem
+
+	for {
+		page, paging, err := client.GetPromotionItems(promotionID, promotionType, status, len(items))
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page...)
+
+		if len(page) == 0 || len(items) >= paging.Total {
+			return items, nil
+		}
+	}
+}
+
+/*GetItemPromotions returns the promotions an item is a candidate of, or offered in*/
+func (client *Client) GetItemPromotions(itemID string) ([]ItemPromotion, error) {
+
+	var promotions []ItemPromotion
+	if err := client.getJSON("/seller-promotions/items/"+url.PathEscape(itemID)+"?app_version=v2", &promotions); err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+/*AddItemToPromotion offers an item in a promotion at the discount price*/
+func (client *Client) AddItemToPromotion(itemID string, offer PromotionOffer) error {
+
+	return client.postJSON("/seller-promotions/items/"+url.PathEscape(itemID)+"?app_version=v2", offer, nil)
+}
+
+/*RemoveItemFromPromotion stops offering an item in a promotion, so it is sold at its original price again*/
+func (client *Client) RemoveItemFromPromotion(itemID string, promotionID string, promotionType PromotionType) error {
+
+	query := url.Values{}
+	query.Set("promotion_type", string(promotionType))
+	if promotionID != "" {
+		query.Set("promotion_id", promotionID)
+	}
+	query.Set("app_version", "v2")
+
+	resp, err := client.Delete("/seller-promotions/items/" + url.PathEscape(itemID) + "?" + query.Encode())
+	if err != nil {
+		return err
+	}
+
+	return decodeResponse(resp, nil)
+}
+
+/*promotionPath returns the path of a promotion, or of one of its resources, with the type and status as params*/
+func promotionPath(promotionID string, resource string, promotionType PromotionType, status PromotionStatus) string {
+
+	query := url.Values{}
+	query.Set("promotion_type", string(promotionType))
+	query.Set("app_version", "v2")
+	if status != "" {
+		query.Set("status", string(status))
+	}
+
+	return "/seller-promotions/promotions/" + url.PathEscape(promotionID) + resource + "?" + query.Encode()
+}
@@ -0,0 +1,137 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+func Test_Items_are_added_to_and_removed_from_promotions(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.AddPromotion(testSellerID, sdktest.Object{"id": "P-1", "type": "DEAL", "name": "Black Friday", "finish_date": "2026-11-30T00:00:00Z"})
+	server.AddPromotion(99, sdktest.Object{"id": "P-2", "type": "DEAL"})
+	server.AddPromotionCandidate("P-1", sdktest.Object{"id": "MLA1", "price": 100, "min_discounted_price": 70, "max_discounted_price": 95})
+	server.AddPromotionCandidate("P-1", sdktest.Object{"id": "MLA2", "price": 50})
+
+	promotions, err := client.GetPromotions()
+
+	if err != nil || len(promotions) != 1 || promotions[0].Type != sdk.PromotionDeal || promotions[0].Status != sdk.PromotionStarted ||
+		promotions[0].FinishDate == nil {
+		log.Printf("Error: unexpected promotions %+v %v", promotions, err)
+		t.FailNow()
+	}
+
+	candidates, paging, err := client.GetPromotionItems("P-1", sdk.PromotionDeal, sdk.PromotionCandidate, 0)
+
+	if err != nil || len(candidates) != 2 || paging.Total != 2 || candidates[0].ID != "MLA1" || !candidates[0].AcceptsPrice(90) ||
+		candidates[0].AcceptsPrice(60) || candidates[0].AcceptsPrice(99) || !candidates[1].AcceptsPrice(1) {
+		log.Printf("Error: unexpected candidates %+v %v", candidates, err)
+		t.FailNow()
+	}
+
+	if err := client.AddItemToPromotion("MLA1", sdk.PromotionOffer{PromotionID: "P-1", Type: sdk.PromotionDeal, DealPrice: 60}); err == nil {
+		log.Printf("Error: an item was added below its minimum discount price")
+		t.FailNow()
+	}
+
+	if err := client.AddItemToPromotion("MLA1", sdk.PromotionOffer{PromotionID: "P-1", Type: sdk.PromotionDeal, DealPrice: 90}); err != nil {
+		log.Printf("Error: item was not added %v", err)
+		t.FailNow()
+	}
+
+	itemPromotions, err := client.GetItemPromotions("MLA1")
+
+	if err != nil || len(itemPromotions) != 1 || itemPromotions[0].Status != sdk.PromotionStarted || itemPromotions[0].Price != 90 ||
+		itemPromotions[0].OriginalPrice != 100 || itemPromotions[0].Name != "Black Friday" {
+		log.Printf("Error: unexpected item promotions %+v %v", itemPromotions, err)
+		t.FailNow()
+	}
+
+	if err := client.RemoveItemFromPromotion("MLA1", "P-1", sdk.PromotionDeal); err != nil {
+		log.Printf("Error: item was not removed %v", err)
+		t.FailNow()
+	}
+
+	candidates, _, _ = client.GetPromotionItems("P-1", sdk.PromotionDeal, "", 0)
+
+	if len(candidates) != 2 || candidates[0].Status != sdk.PromotionCandidate || candidates[0].Price != 100 {
+		log.Printf("Error: unexpected items after removal %+v", candidates)
+		t.FailNow()
+	}
+
+	if _, err := client.GetPromotion("P-2", sdk.PromotionDeal); err == nil {
+		log.Printf("Error: a promotion of another seller was read")
+		t.FailNow()
+	}
+}
+
+func Test_Every_page_of_the_items_of_a_promotion_is_read(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.AddPromotion(testSellerID, sdktest.Object{"id": "P-1", "type": "DEAL"})
+	for i := 1; i <= 60; i++ {
+		server.AddPromotionCandidate("P-1", sdktest.Object{"id": fmt.Sprintf("MLA%02d", i), "price": 100})
+	}
+
+	candidates, paging, err := client.GetPromotionItems("P-1", sdk.PromotionDeal, sdk.PromotionCandidate, 50)
+
+	if err != nil || len(candidates) != 10 || paging.Total != 60 || candidates[0].ID != "MLA51" {
+		log.Printf("Error: unexpected page %+v %+v %v", candidates, paging, err)
+		t.FailNow()
+	}
+
+	if all, err := client.GetAllPromotionItems("P-1", sdk.PromotionDeal, sdk.PromotionCandidate); err != nil || len(all) != 60 {
+		log.Printf("Error: expected 60 items obtained %d %v", len(all), err)
+		t.FailNow()
+	}
+}
+
+func Test_Price_discount_is_created_for_an_item(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.AddItem(sdktest.Object{"id": "MLA1", "seller_id": testSellerID, "price": 100})
+
+	start := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	finish := start.AddDate(0, 0, 7)
+
+	offer := sdk.PromotionOffer{Type: sdk.PromotionPriceDiscount, DealPrice: 80, StartDate: &start, FinishDate: &finish}
+	if err := client.AddItemToPromotion("MLA1", offer); err != nil {
+		log.Printf("Error: price discount was not created %v", err)
+		t.FailNow()
+	}
+
+	promotions, err := client.GetItemPromotions("MLA1")
+
+	if err != nil || len(promotions) != 1 || promotions[0].Type != sdk.PromotionPriceDiscount || promotions[0].Price != 80 ||
+		promotions[0].OriginalPrice != 100 || promotions[0].FinishDate == nil || !promotions[0].FinishDate.Equal(finish) {
+		log.Printf("Error: unexpected promotions %+v %v", promotions, err)
+		t.FailNow()
+	}
+}
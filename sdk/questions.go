@@ -0,0 +1,101 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+/*Question is asked by a user about an item, and answered by its seller*/
+type Question struct {
+	ID          int64     `json:"id"`
+	ItemID      string    `json:"item_id"`
+	SellerID    int64     `json:"seller_id"`
+	Text        string    `json:"text"`
+	Status      string    `json:"status"`
+	DateCreated time.Time `json:"date_created"`
+	From        struct {
+		ID int64 `json:"id"`
+	} `json:"from"`
+	Answer *Answer `json:"answer"`
+}
+
+/*Answer is the reply of the seller to a question*/
+type Answer struct {
+	Text        string    `json:"text"`
+	Status      string    `json:"status"`
+	DateCreated time.Time `json:"date_created"`
+}
+
+/*GetQuestions returns the questions asked about the given item*/
+func (client *Client) GetQuestions(itemID string) ([]Question, error) {
+
+	var result struct {
+		Questions []Question `json:"questions"`
+	}
+
+	if err := client.getJSON("/questions/search?item="+url.QueryEscape(itemID), &result); err != nil {
+		return nil, err
+	}
+
+	return result.Questions, nil
+}
+
+/*GetQuestion returns the question with the given id*/
+func (client *Client) GetQuestion(questionID int64) (*Question, error) {
+
+	question := new(Question)
+	if err := client.getJSON("/questions/"+strconv.FormatInt(questionID, 10), question); err != nil {
+		return nil, err
+	}
+
+	return question, nil
+}
+
+/*AskQuestion asks the seller of the given item a question, and returns it*/
+func (client *Client) AskQuestion(itemID string, text string) (*Question, error) {
+
+	request := struct {
+		ItemID string `json:"item_id"`
+		Text   string `json:"text"`
+	}{itemID, text}
+
+	question := new(Question)
+	if err := client.postJSON("/questions", request, question); err != nil {
+		return nil, err
+	}
+
+	return question, nil
+}
+
+/*AnswerQuestion answers a question about an item of the user the client acts on behalf of, and returns it*/
+func (client *Client) AnswerQuestion(questionID int64, text string) (*Question, error) {
+
+	request := struct {
+		QuestionID int64  `json:"question_id"`
+		Text       string `json:"text"`
+	}{questionID, text}
+
+	question := new(Question)
+	if err := client.postJSON("/answers", request, question); err != nil {
+		return nil, err
+	}
+
+	return question, nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"log"
+	"testing"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+func Test_Questions_are_asked_listed_and_answered(t *testing.T) {
+
+	server, seller := newSellerClient(t)
+	defer server.Close()
+
+	server.AddUser(sdktest.User{ID: 1, Nickname: "TEST_BUYER"})
+	server.AddItem(sdktest.Object{"id": "MLA1", "seller_id": testSellerID, "title": "Item"})
+
+	buyer, _ := sdk.NewClientManager(sdk.ManagerConfig{}).Client(server.Config(server.NewCode(1)))
+
+	question, err := buyer.AskQuestion("MLA1", "Is it new?")
+
+	if err != nil || question.Status != "UNANSWERED" || question.From.ID != 1 {
+		log.Printf("Error: unexpected question %+v %v", question, err)
+		t.FailNow()
+	}
+
+	questions, err := seller.GetQuestions("MLA1")
+
+	if err != nil || len(questions) != 1 || questions[0].ID != question.ID {
+		log.Printf("Error: unexpected questions %+v %v", questions, err)
+		t.FailNow()
+	}
+
+	answered, err := seller.AnswerQuestion(question.ID, "Yes")
+
+	if err != nil || answered.Answer == nil || answered.Answer.Text != "Yes" || answered.Status != "ANSWERED" {
+		log.Printf("Error: question was not answered %+v %v", answered, err)
+		t.FailNow()
+	}
+
+	if _, err := buyer.AnswerQuestion(question.ID, "Nope"); err == nil {
+		log.Printf("Error: only the seller should be able to answer")
+		t.FailNow()
+	}
+}
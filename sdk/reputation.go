@@ -0,0 +1,237 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+)
+
+/*The reputation metrics a ReputationMonitor can watch, all of them rates from 0 to 1*/
+const (
+	MetricClaims              = "claims"
+	MetricCancellations       = "cancellations"
+	MetricDelayedHandlingTime = "delayed_handling_time"
+)
+
+/*User is a MercadoLibre user, along with its reputation as a seller*/
+type User struct {
+	ID               int64             `json:"id"`
+	Nickname         string            `json:"nickname"`
+	SiteID           string            `json:"site_id"`
+	Email            string            `json:"email,omitempty"`
+	SellerReputation *SellerReputation `json:"seller_reputation,omitempty"`
+}
+
+/*SellerReputation is the reputation of a seller, i.e. level 5_green and power seller status platinum*/
+type SellerReputation struct {
+	LevelID           string                  `json:"level_id"`
+	PowerSellerStatus string                  `json:"power_seller_status"`
+	Transactions      ReputationTransactions  `json:"transactions"`
+	Metrics           SellerReputationMetrics `json:"metrics"`
+}
+
+/*ReputationTransactions are the sales of a seller, in total and rated by the buyers*/
+type ReputationTransactions struct {
+	Period    string `json:"period"`
+	Total     int    `json:"total"`
+	Completed int    `json:"completed"`
+	Canceled  int    `json:"canceled"`
+	Ratings   struct {
+		Positive float64 `json:"positive"`
+		Neutral  float64 `json:"neutral"`
+		Negative float64 `json:"negative"`
+	} `json:"ratings"`
+}
+
+/*SellerReputationMetrics are the rates the reputation level of a seller is computed from*/
+type SellerReputationMetrics struct {
+	Sales struct {
+		Period    string `json:"period"`
+		Completed int    `json:"completed"`
+	} `json:"sales"`
+	Claims              ReputationRate `json:"claims"`
+	Cancellations       ReputationRate `json:"cancellations"`
+	DelayedHandlingTime ReputationRate `json:"delayed_handling_time"`
+}
+
+/*ReputationRate is how many sales of a period had a problem, i.e. a claim, both as a count and as a rate from 0 to 1*/
+type ReputationRate struct {
+	Period string  `json:"period"`
+	Rate   float64 `json:"rate"`
+	Value  int     `json:"value"`
+}
+
+/*Rate returns the rate of one of the metrics, i.e. MetricClaims, and whether the metric exists*/
+func (metrics SellerReputationMetrics) Rate(metric string) (float64, bool) {
+
+	switch metric {
+	case MetricClaims:
+		return metrics.Claims.Rate, true
+	case MetricCancellations:
+		return metrics.Cancellations.Rate, true
+	case MetricDelayedHandlingTime:
+		return metrics.DelayedHandlingTime.Rate, true
+	}
+
+	return 0, false
+}
+
+/*GetUser returns a user, with its reputation as a seller*/
+func (client *Client) GetUser(userID int64) (*User, error) {
+
+	user := new(User)
+	if err := client.getJSON("/users/"+strconv.FormatInt(userID, 10), user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+/*GetSellerReputation returns the reputation of a seller. It is empty for users who never sold.*/
+func (client *Client) GetSellerReputation(userID int64) (*SellerReputation, error) {
+
+	user, err := client.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.SellerReputation == nil {
+		return new(SellerReputation), nil
+	}
+
+	return user.SellerReputation, nil
+}
+
+type ReputationMonitorConfig struct {
+	//UserID is the seller to watch. It defaults to the user the client acts on behalf of.
+	UserID int64
+	//Interval is the time between polls. It defaults to one hour, since the metrics are recomputed daily.
+	Interval time.Duration
+	//Thresholds are the maximum acceptable rate of each metric, i.e. {MetricClaims: 0.02}.
+	Thresholds map[string]float64
+}
+
+/*
+ReputationEvent is sent when a metric crosses its threshold, either exceeding it or going back below it,
+or when the reputation could not be read, in which case Err is set.
+*/
+type ReputationEvent struct {
+	Metric     string
+	Rate       float64
+	Threshold  float64
+	Exceeded   bool
+	Reputation *SellerReputation
+	Err        error
+}
+
+/*ReputationMonitor polls the reputation of a seller and reports the metrics crossing their thresholds*/
+type ReputationMonitor struct {
+	client *Client
+	config ReputationMonitorConfig
+}
+
+/*NewReputationMonitor returns a ReputationMonitor which reads the reputation through the given client*/
+func NewReputationMonitor(client *Client, config ReputationMonitorConfig) *ReputationMonitor {
+
+	if config.UserID == 0 {
+		config.UserID = client.UserID()
+	}
+
+	if config.Interval <= 0 {
+		config.Interval = time.Hour
+	}
+
+	return &ReputationMonitor{client: client, config: config}
+}
+
+/*
+Run polls the reputation right away and then on every interval, until ctx is done, when the events channel
+is closed. A metric already above its threshold on the first poll is reported as exceeded.
+*/
+func (monitor *ReputationMonitor) Run(ctx context.Context) <-chan ReputationEvent {
+
+	events := make(chan ReputationEvent)
+
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(monitor.config.Interval)
+		defer ticker.Stop()
+
+		exceeded := make(map[string]bool)
+
+		for {
+			for _, event := range monitor.poll(exceeded) {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+/*poll reads the reputation and returns an event for every metric whose state differs from the exceeded ones*/
+func (monitor *ReputationMonitor) poll(exceeded map[string]bool) []ReputationEvent {
+
+	reputation, err := monitor.client.GetSellerReputation(monitor.config.UserID)
+	if err != nil {
+		return []ReputationEvent{{Err: err}}
+	}
+
+	metrics := make([]string, 0, len(monitor.config.Thresholds))
+	for metric := range monitor.config.Thresholds {
+		metrics = append(metrics, metric)
+	}
+
+	sort.Strings(metrics)
+
+	var events []ReputationEvent
+
+	for _, metric := range metrics {
+
+		threshold := monitor.config.Thresholds[metric]
+		rate, ok := reputation.Metrics.Rate(metric)
+		if !ok || (rate > threshold) == exceeded[metric] {
+			continue
+		}
+
+		exceeded[metric] = rate > threshold
+
+		events = append(events, ReputationEvent{
+			Metric:     metric,
+			Rate:       rate,
+			Threshold:  threshold,
+			Exceeded:   exceeded[metric],
+			Reputation: reputation,
+		})
+	}
+
+	return events
+}
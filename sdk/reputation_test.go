@@ -0,0 +1,113 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+func reputationWithClaims(rate float64) sdktest.Object {
+	return sdktest.Object{
+		"level_id":            "5_green",
+		"power_seller_status": "platinum",
+		"metrics": sdktest.Object{
+			"claims":                sdktest.Object{"period": "60 days", "rate": rate, "value": 1},
+			"cancellations":         sdktest.Object{"period": "60 days", "rate": 0.005, "value": 1},
+			"delayed_handling_time": sdktest.Object{"period": "60 days", "rate": 0.1, "value": 20},
+		},
+	}
+}
+
+func Test_Seller_reputation_is_read(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.SetSellerReputation(testSellerID, reputationWithClaims(0.01))
+
+	reputation, err := client.GetSellerReputation(testSellerID)
+
+	if err != nil || reputation.LevelID != "5_green" || reputation.PowerSellerStatus != "platinum" ||
+		reputation.Metrics.Claims.Rate != 0.01 || reputation.Metrics.DelayedHandlingTime.Value != 20 {
+		log.Printf("Error: unexpected reputation %+v %v", reputation, err)
+		t.FailNow()
+	}
+
+	if rate, ok := reputation.Metrics.Rate(sdk.MetricCancellations); !ok || rate != 0.005 {
+		log.Printf("Error: unexpected cancellations rate %f", rate)
+		t.FailNow()
+	}
+}
+
+func Test_Reputation_monitor_reports_metrics_crossing_their_thresholds(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.SetSellerReputation(testSellerID, reputationWithClaims(0.01))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	monitor := sdk.NewReputationMonitor(client, sdk.ReputationMonitorConfig{
+		Interval:   10 * time.Millisecond,
+		Thresholds: map[string]float64{sdk.MetricClaims: 0.02, sdk.MetricDelayedHandlingTime: 0.08},
+	})
+
+	events := monitor.Run(ctx)
+
+	next := func() sdk.ReputationEvent {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(5 * time.Second):
+			log.Printf("Error: no event was reported")
+			t.FailNow()
+		}
+		return sdk.ReputationEvent{}
+	}
+
+	if event := next(); event.Metric != sdk.MetricDelayedHandlingTime || !event.Exceeded || event.Rate != 0.1 || event.Threshold != 0.08 {
+		log.Printf("Error: unexpected first event %+v", event)
+		t.FailNow()
+	}
+
+	server.SetSellerReputation(testSellerID, reputationWithClaims(0.03))
+
+	if event := next(); event.Metric != sdk.MetricClaims || !event.Exceeded || event.Rate != 0.03 || event.Reputation == nil {
+		log.Printf("Error: unexpected exceeded event %+v", event)
+		t.FailNow()
+	}
+
+	server.SetSellerReputation(testSellerID, reputationWithClaims(0.01))
+
+	if event := next(); event.Metric != sdk.MetricClaims || event.Exceeded || event.Rate != 0.01 {
+		log.Printf("Error: unexpected recovered event %+v", event)
+		t.FailNow()
+	}
+
+	cancel()
+
+	for range events {
+	}
+}
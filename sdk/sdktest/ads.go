@@ -0,0 +1,253 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdktest
+
+import (
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+/*AddAdvertiser keeps the Product Ads account of a user, i.e. {"advertiser_id": 1, "site_id": "MLA"}*/
+func (server *Server) AddAdvertiser(userID int64, advertiser Object) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	advertiser = normalize(advertiser)
+	advertiser["user_id"] = userID
+
+	server.advertisers[toInt64(advertiser["advertiser_id"])] = advertiser
+}
+
+/*AddCampaign keeps a campaign of an advertiser, i.e. {"id": 1, "name": "Summer", "status": "active"}*/
+func (server *Server) AddCampaign(advertiserID int64, campaign Object) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	campaign = normalize(campaign)
+	campaign["advertiser_id"] = advertiserID
+
+	server.campaigns[toInt64(campaign["id"])] = campaign
+}
+
+/*AddAd keeps an ad of an advertiser, i.e. {"item_id": "MLA1", "campaign_id": 1, "status": "active"}*/
+func (server *Server) AddAd(advertiserID int64, ad Object) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	ad = normalize(ad)
+	ad["advertiser_id"] = advertiserID
+
+	server.ads[ad["item_id"].(string)] = ad
+}
+
+/*
+AddAdMetrics adds the metrics of the ad of an item on the given day, in UTC, i.e. {"clicks": 10, "prints": 100,
+"cost": 25.5, "units_quantity": 1, "total_amount": 300}. CTR, CPC and ACOS are computed when read.
+*/
+func (server *Server) AddAdMetrics(itemID string, day time.Time, metrics Object) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	days, ok := server.adMetrics[itemID]
+	if !ok {
+		days = make(map[string]Object)
+		server.adMetrics[itemID] = days
+	}
+
+	key := day.UTC().Format(dayLayout)
+	total, ok := days[key]
+	if !ok {
+		total = Object{}
+		days[key] = total
+	}
+
+	for _, name := range []string{"clicks", "prints", "cost", "units_quantity", "total_amount"} {
+		total[name] = toFloat64(total[name]) + toFloat64(metrics[name])
+	}
+}
+
+func (server *Server) routeAdvertising(r *http.Request, segments []string, query url.Values) (int, interface{}) {
+
+	caller, authorized := server.caller(r)
+	if !authorized {
+		return unauthorized()
+	}
+
+	if r.Method != http.MethodGet {
+		return notFound()
+	}
+
+	if len(segments) == 2 && segments[1] == "advertisers" {
+
+		if query.Get("product_id") != "PADS" {
+			return apiError(http.StatusBadRequest, "bad_request", "product_id is required")
+		}
+
+		advertisers := []Object{}
+		for _, advertiser := range server.advertisers {
+			if toInt64(advertiser["user_id"]) == caller {
+				advertisers = append(advertisers, advertiser)
+			}
+		}
+
+		return http.StatusOK, Object{"advertisers": advertisers}
+	}
+
+	from, to := query.Get("date_from"), query.Get("date_to")
+	if from == "" || to == "" {
+		return apiError(http.StatusBadRequest, "bad_request", "date_from and date_to are required")
+	}
+
+	switch {
+	case len(segments) == 4 && segments[1] == "product_ads" && segments[2] == "campaigns":
+
+		campaign, ok := server.campaigns[toInt64(segments[3])]
+		if !ok || !server.advertises(caller, campaign) {
+			return notFound()
+		}
+
+		return http.StatusOK, Object{"results": server.dailyMetrics(campaign, from, to)}
+
+	case len(segments) >= 5 && segments[1] == "advertisers" && segments[3] == "product_ads":
+
+		advertiserID := toInt64(segments[2])
+		if advertiser, ok := server.advertisers[advertiserID]; !ok || toInt64(advertiser["user_id"]) != caller {
+			return notFound()
+		}
+
+		var results []Object
+
+		switch {
+		case len(segments) == 5 && segments[4] == "campaigns":
+			for _, campaign := range server.campaigns {
+				if toInt64(campaign["advertiser_id"]) == advertiserID {
+					campaign = copyObject(campaign)
+					campaign["metrics"] = server.campaignMetrics(campaign, from, to)
+					results = append(results, campaign)
+				}
+			}
+			sortByID(results)
+
+		case len(segments) == 6 && segments[4] == "ads" && segments[5] == "search":
+			for itemID, ad := range server.ads {
+				if toInt64(ad["advertiser_id"]) == advertiserID {
+					ad = copyObject(ad)
+					ad["metrics"] = server.sumAdMetrics([]string{itemID}, from, to)
+					results = append(results, ad)
+				}
+			}
+			sort.Slice(results, func(i, j int) bool { return results[i]["item_id"].(string) < results[j]["item_id"].(string) })
+
+		default:
+			return notFound()
+		}
+
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+
+		return http.StatusOK, Object{"results": page(results, offset, limit), "paging": Object{"total": len(results), "offset": offset, "limit": limit}}
+	}
+
+	return notFound()
+}
+
+/*advertises tells whether the campaign belongs to an advertiser of the user*/
+func (server *Server) advertises(userID int64, campaign Object) bool {
+
+	advertiser, ok := server.advertisers[toInt64(campaign["advertiser_id"])]
+	return ok && toInt64(advertiser["user_id"]) == userID
+}
+
+/*campaignItems returns the items advertised in the campaign*/
+func (server *Server) campaignItems(campaign Object) []string {
+
+	itemIDs := []string{}
+	for itemID, ad := range server.ads {
+		if toInt64(ad["campaign_id"]) == toInt64(campaign["id"]) {
+			itemIDs = append(itemIDs, itemID)
+		}
+	}
+
+	return itemIDs
+}
+
+func (server *Server) campaignMetrics(campaign Object, from string, to string) Object {
+	return server.sumAdMetrics(server.campaignItems(campaign), from, to)
+}
+
+func (server *Server) dailyMetrics(campaign Object, from string, to string) []Object {
+
+	itemIDs := server.campaignItems(campaign)
+
+	start, _ := time.Parse(dayLayout, from)
+	end, _ := time.Parse(dayLayout, to)
+
+	results := []Object{}
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		day := date.Format(dayLayout)
+		metrics := server.sumAdMetrics(itemIDs, day, day)
+		metrics["date"] = day
+		results = append(results, metrics)
+	}
+
+	return results
+}
+
+/*sumAdMetrics returns the metrics of the ads of the items between both days, both included*/
+func (server *Server) sumAdMetrics(itemIDs []string, from string, to string) Object {
+
+	var clicks, prints, cost, units, amount float64
+
+	for _, itemID := range itemIDs {
+		for day, metrics := range server.adMetrics[itemID] {
+			if day >= from && day <= to {
+				clicks += toFloat64(metrics["clicks"])
+				prints += toFloat64(metrics["prints"])
+				cost += toFloat64(metrics["cost"])
+				units += toFloat64(metrics["units_quantity"])
+				amount += toFloat64(metrics["total_amount"])
+			}
+		}
+	}
+
+	metrics := Object{"clicks": clicks, "prints": prints, "cost": round(cost), "units_quantity": units, "total_amount": round(amount),
+		"ctr": 0.0, "cpc": 0.0, "acos": 0.0}
+
+	if prints > 0 {
+		metrics["ctr"] = round(clicks / prints * 100)
+	}
+	if clicks > 0 {
+		metrics["cpc"] = round(cost / clicks)
+	}
+	if amount > 0 {
+		metrics["acos"] = round(cost / amount * 100)
+	}
+
+	return metrics
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
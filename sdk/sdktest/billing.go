@@ -0,0 +1,131 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdktest
+
+import (
+	"net/http"
+	"strconv"
+)
+
+/*SetBillingInfo sets the fiscal data of the buyer of an order, i.e. {"doc_type": "CPF", "doc_number": "12345678909"}*/
+func (server *Server) SetBillingInfo(orderID int64, billingInfo Object) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.billingInfo[orderID] = normalize(billingInfo)
+}
+
+/*SetOrderInvoice sets the invoice issued for an order, i.e. {"id": 1, "status": "authorized"}*/
+func (server *Server) SetOrderInvoice(orderID int64, invoice Object) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.invoices[orderID] = normalize(invoice)
+}
+
+/*FiscalDocuments returns the fiscal documents uploaded to a pack, by the id the fake API assigned them*/
+func (server *Server) FiscalDocuments(packID int64) map[string]File {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	documents := make(map[string]File, len(server.fiscalDocuments[packID]))
+	for id, document := range server.fiscalDocuments[packID] {
+		documents[id] = document
+	}
+
+	return documents
+}
+
+func (server *Server) billingInfoOf(order Object) (int, interface{}) {
+
+	billingInfo, ok := server.billingInfo[toInt64(order["id"])]
+	if !ok {
+		return notFound()
+	}
+
+	return http.StatusOK, Object{"billing_info": billingInfo}
+}
+
+func (server *Server) invoiceOf(seller int64, orderID int64) (int, interface{}) {
+
+	order, ok := server.orders[orderID]
+	if !ok || sellerID(order) != seller {
+		return notFound()
+	}
+
+	invoice, ok := server.invoices[orderID]
+	if !ok {
+		return notFound()
+	}
+
+	return http.StatusOK, invoice
+}
+
+/*routePacks implements the fiscal documents of a pack, which is either the pack_id of its orders or the id of an order without pack*/
+func (server *Server) routePacks(r *http.Request, segments []string, body []byte) (int, interface{}) {
+
+	caller, authorized := server.caller(r)
+	if !authorized {
+		return unauthorized()
+	}
+
+	if len(segments) < 3 || segments[2] != "fiscal_documents" {
+		return notFound()
+	}
+
+	packID, _ := strconv.ParseInt(segments[1], 10, 64)
+
+	sold := false
+	for id, order := range server.orders {
+		if (toInt64(order["pack_id"]) == packID || (order["pack_id"] == nil && id == packID)) && sellerID(order) == caller {
+			sold = true
+		}
+	}
+
+	if !sold {
+		return notFound()
+	}
+
+	switch {
+	case len(segments) == 3 && r.Method == http.MethodPost:
+
+		document, err := formFile(r, body, "fiscal_document")
+		if err != nil {
+			return apiError(http.StatusBadRequest, "bad_request", err.Error())
+		}
+
+		id := server.nextID()
+
+		if server.fiscalDocuments[packID] == nil {
+			server.fiscalDocuments[packID] = make(map[string]File)
+		}
+		server.fiscalDocuments[packID][id] = document
+
+		return http.StatusCreated, Object{"ids": []string{id}}
+
+	case len(segments) == 4 && r.Method == http.MethodGet:
+
+		if document, ok := server.fiscalDocuments[packID][segments[3]]; ok {
+			return http.StatusOK, document
+		}
+	}
+
+	return notFound()
+}
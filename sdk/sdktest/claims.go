@@ -0,0 +1,259 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdktest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+/*
+AddClaim keeps the given claim. An id is assigned if it has none, and it is opened in the claim stage unless
+told otherwise. Only its players, i.e. {"role": "respondent", "type": "seller", "user_id": 1}, can read it.
+*/
+func (server *Server) AddClaim(claim Object) Object {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	claim = normalize(claim)
+
+	if _, ok := claim["id"]; !ok {
+		claim["id"], _ = strconv.ParseInt(server.nextID(), 10, 64)
+	}
+	if _, ok := claim["status"]; !ok {
+		claim["status"] = "opened"
+	}
+	if _, ok := claim["stage"]; !ok {
+		claim["stage"] = "claim"
+	}
+	if _, ok := claim["date_created"]; !ok {
+		claim["date_created"] = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	server.claims[toInt64(claim["id"])] = claim
+	return claim
+}
+
+/*AddClaimEvidence adds an evidence to a claim, i.e. {"type": "shipping_evidence"}*/
+func (server *Server) AddClaimEvidence(claimID int64, evidence Object) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.claimEvidences[claimID] = append(server.claimEvidences[claimID], map[string]interface{}(normalize(evidence)))
+}
+
+/*SetClaimReturn sets the return of the items of a claim, i.e. {"status": "shipped"}*/
+func (server *Server) SetClaimReturn(claimID int64, claimReturn Object) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	claimReturn = normalize(claimReturn)
+	claimReturn["claim_id"] = claimID
+	server.returns[claimID] = claimReturn
+}
+
+/*ClaimMessages returns the messages sent within a claim*/
+func (server *Server) ClaimMessages(claimID int64) []Object {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	messages := make([]Object, 0, len(server.claimMessages[claimID]))
+	for _, message := range server.claimMessages[claimID] {
+		messages = append(messages, copyObject(message.(map[string]interface{})))
+	}
+
+	return messages
+}
+
+/*ClaimAttachments returns the files uploaded to a claim, by the filename the fake API assigned them*/
+func (server *Server) ClaimAttachments(claimID int64) map[string]File {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	attachments := make(map[string]File, len(server.attachments[claimID]))
+	for filename, file := range server.attachments[claimID] {
+		attachments[filename] = file
+	}
+
+	return attachments
+}
+
+func (server *Server) routeClaims(r *http.Request, segments []string, query url.Values, body []byte) (int, interface{}) {
+
+	caller, authorized := server.caller(r)
+	if !authorized {
+		return unauthorized()
+	}
+
+	if len(segments) < 4 || segments[2] != "claims" {
+		return notFound()
+	}
+
+	if segments[1] == "v1" && segments[3] == "search" && len(segments) == 4 && r.Method == http.MethodGet {
+		return server.searchClaims(caller, query)
+	}
+
+	claimID, _ := strconv.ParseInt(segments[3], 10, 64)
+	claim, ok := server.claims[claimID]
+	if !ok {
+		return notFound()
+	}
+
+	player := claimPlayer(claim, caller)
+	if player == nil {
+		return apiError(http.StatusForbidden, "forbidden", "the caller is not a player of the claim")
+	}
+
+	action := segments[4:]
+
+	switch {
+	case segments[1] == "v2" && len(action) == 1 && action[0] == "returns" && r.Method == http.MethodGet:
+		if claimReturn, ok := server.returns[claimID]; ok {
+			return http.StatusOK, claimReturn
+		}
+		return notFound()
+
+	case segments[1] != "v1":
+		return notFound()
+
+	case len(action) == 0 && r.Method == http.MethodGet:
+		return http.StatusOK, claim
+
+	case len(action) == 1 && action[0] == "messages" && r.Method == http.MethodGet:
+		return http.StatusOK, append([]interface{}{}, server.claimMessages[claimID]...)
+
+	case len(action) == 1 && action[0] == "evidences" && r.Method == http.MethodGet:
+		return http.StatusOK, append([]interface{}{}, server.claimEvidences[claimID]...)
+
+	case len(action) == 1 && action[0] == "attachments" && r.Method == http.MethodPost:
+		return server.uploadAttachment(r, claimID, caller, body)
+
+	case len(action) == 2 && action[0] == "actions" && action[1] == "send-message" && r.Method == http.MethodPost:
+		return server.sendClaimMessage(claimID, player, body)
+	}
+
+	return notFound()
+}
+
+func (server *Server) searchClaims(caller int64, query url.Values) (int, interface{}) {
+
+	claims := []Object{}
+	for _, claim := range server.claims {
+
+		if claimPlayer(claim, caller) == nil {
+			continue
+		}
+
+		if (query.Get("status") != "" && claim["status"] != query.Get("status")) ||
+			(query.Get("stage") != "" && claim["stage"] != query.Get("stage")) ||
+			(query.Get("type") != "" && claim["type"] != query.Get("type")) ||
+			(query.Get("resource_id") != "" && toInt64(claim["resource_id"]) != toInt64(query.Get("resource_id"))) {
+			continue
+		}
+
+		claims = append(claims, claim)
+	}
+
+	sortByID(claims)
+
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	return http.StatusOK, Object{"data": page(claims, offset, limit), "paging": Object{"total": len(claims), "offset": offset, "limit": limit}}
+}
+
+func (server *Server) uploadAttachment(r *http.Request, claimID int64, caller int64, body []byte) (int, interface{}) {
+
+	file, err := formFile(r, body, "file")
+	if err != nil {
+		return apiError(http.StatusBadRequest, "bad_request", err.Error())
+	}
+
+	filename := server.nextID() + "-" + file.Name
+
+	if server.attachments[claimID] == nil {
+		server.attachments[claimID] = make(map[string]File)
+	}
+	server.attachments[claimID][filename] = file
+
+	return http.StatusOK, Object{"user_id": caller, "filename": filename}
+}
+
+func (server *Server) sendClaimMessage(claimID int64, player map[string]interface{}, body []byte) (int, interface{}) {
+
+	var request struct {
+		ReceiverRole string   `json:"receiver_role"`
+		Message      string   `json:"message"`
+		Attachments  []string `json:"attachments"`
+	}
+
+	if json.Unmarshal(body, &request) != nil || request.ReceiverRole == "" || request.Message == "" {
+		return apiError(http.StatusBadRequest, "bad_request", "receiver_role and message are required")
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	attachments := []interface{}{}
+	for _, filename := range request.Attachments {
+
+		file, ok := server.attachments[claimID][filename]
+		if !ok {
+			return apiError(http.StatusBadRequest, "bad_request", "attachment "+filename+" was not uploaded")
+		}
+
+		attachments = append(attachments, map[string]interface{}{
+			"filename":          filename,
+			"original_filename": file.Name,
+			"type":              file.ContentType,
+			"size":              len(file.Content),
+			"date_created":      now,
+		})
+	}
+
+	message := map[string]interface{}{
+		"sender_role":   player["role"],
+		"receiver_role": request.ReceiverRole,
+		"message":       request.Message,
+		"attachments":   attachments,
+		"date_created":  now,
+	}
+
+	server.claimMessages[claimID] = append(server.claimMessages[claimID], message)
+
+	return http.StatusCreated, message
+}
+
+/*claimPlayer returns the player of the claim who is the given user, or nil if there is none*/
+func claimPlayer(claim Object, userID int64) map[string]interface{} {
+
+	players, _ := claim["players"].([]interface{})
+	for _, value := range players {
+		if player, ok := value.(map[string]interface{}); ok && toInt64(player["user_id"]) == userID {
+			return player
+		}
+	}
+
+	return nil
+}
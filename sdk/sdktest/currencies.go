@@ -0,0 +1,82 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdktest
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+)
+
+/*currencies are the currencies returned by the fake API*/
+var currencies = []Object{
+	{"id": "ARS", "symbol": "$", "description": "Peso argentino", "decimal_places": 2},
+	{"id": "BRL", "symbol": "R$", "description": "Real", "decimal_places": 2},
+	{"id": "CLP", "symbol": "$", "description": "Peso Chileno", "decimal_places": 0},
+	{"id": "COP", "symbol": "$", "description": "Peso colombiano", "decimal_places": 2},
+	{"id": "EUR", "symbol": "€", "description": "Euro", "decimal_places": 2},
+	{"id": "MXN", "symbol": "$", "description": "Peso Mexicano", "decimal_places": 2},
+	{"id": "USD", "symbol": "U$S", "description": "Dólar", "decimal_places": 2},
+	{"id": "UYU", "symbol": "$", "description": "Peso Uruguayo", "decimal_places": 2},
+}
+
+/*SetConversionRate sets the ratio to convert amounts from one currency to another, i.e. 1 USD is 950.5 ARS*/
+func (server *Server) SetConversionRate(from string, to string, ratio float64) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.conversions[from+"/"+to] = ratio
+}
+
+func (server *Server) routeCurrencies(segments []string) (int, interface{}) {
+
+	switch len(segments) {
+	case 1:
+		return http.StatusOK, currencies
+	case 2:
+		for _, currency := range currencies {
+			if currency["id"] == segments[1] {
+				return http.StatusOK, currency
+			}
+		}
+	}
+
+	return notFound()
+}
+
+func (server *Server) currencyConversion(query url.Values) (int, interface{}) {
+
+	from, to := query.Get("from"), query.Get("to")
+
+	ratio, ok := server.conversions[from+"/"+to]
+	if !ok {
+		return apiError(http.StatusNotFound, "not_found", "no conversion from "+from+" to "+to)
+	}
+
+	now := time.Now().UTC()
+
+	return http.StatusOK, Object{
+		"currency_base":  from,
+		"currency_quote": to,
+		"ratio":          ratio,
+		"rate":           ratio,
+		"inv_rate":       1 / ratio,
+		"creation_date":  now.Format(time.RFC3339),
+		"valid_until":    now.Add(time.Hour).Format(time.RFC3339),
+	}
+}
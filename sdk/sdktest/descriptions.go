@@ -0,0 +1,68 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdktest
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+/*routeDescription handles the description of an item, which has to be created before it can be updated*/
+func (server *Server) routeDescription(r *http.Request, itemID string, body []byte) (int, interface{}) {
+
+	description, exists := server.descriptions[itemID]
+
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			return notFound()
+		}
+		return http.StatusOK, description
+
+	case http.MethodPost, http.MethodPut:
+		if r.Method == http.MethodPost && exists {
+			return apiError(http.StatusBadRequest, "item.description.already_exists", "The item already has a description, update it instead")
+		}
+
+		if r.Method == http.MethodPut && !exists {
+			return notFound()
+		}
+
+		var request struct {
+			PlainText *string `json:"plain_text"`
+		}
+		if err := json.Unmarshal(body, &request); err != nil || request.PlainText == nil {
+			return apiError(http.StatusBadRequest, "bad_request", "plain_text is required")
+		}
+
+		now := time.Now().UTC().Format(time.RFC3339)
+
+		if !exists {
+			description = Object{"date_created": now}
+			server.descriptions[itemID] = description
+		}
+
+		description["text"] = ""
+		description["plain_text"] = *request.PlainText
+		description["last_updated"] = now
+
+		return http.StatusOK, description
+	}
+
+	return notFound()
+}
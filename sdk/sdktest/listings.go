@@ -0,0 +1,142 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdktest
+
+import (
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+)
+
+/*
+SaleFee is what the fake API charges for selling with a listing type: a percentage of the price, plus a fixed fee
+for the items cheaper than FixedFeeThreshold.
+*/
+type SaleFee struct {
+	Name              string
+	Exposure          string
+	Percentage        float64
+	FixedFee          float64
+	FixedFeeThreshold float64
+	//ListingFee is charged once per listing, instead of per sale
+	ListingFee float64
+}
+
+func defaultSaleFees() map[string]SaleFee {
+	return map[string]SaleFee{
+		"free":         {Name: "Gratuita", Exposure: "lowest"},
+		"gold_special": {Name: "Clásica", Exposure: "highest", Percentage: 13, FixedFee: 900, FixedFeeThreshold: 15000},
+		"gold_pro":     {Name: "Premium", Exposure: "highest", Percentage: 17, FixedFee: 900, FixedFeeThreshold: 15000},
+	}
+}
+
+/*SetSaleFee changes the fee of a listing type, or adds a listing type, for every site*/
+func (server *Server) SetSaleFee(listingTypeID string, fee SaleFee) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.saleFees[listingTypeID] = fee
+}
+
+/*routeListings handles the listing types, exposures and prices of a site*/
+func (server *Server) routeListings(segments []string, query url.Values) (int, interface{}) {
+
+	if len(segments) != 3 {
+		return notFound()
+	}
+
+	site, ok := sdk.LookupSite(segments[1])
+	if !ok {
+		return notFound()
+	}
+
+	ids := make([]string, 0, len(server.saleFees))
+	for id := range server.saleFees {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	switch segments[2] {
+	case "listing_types":
+		types := []Object{}
+		for _, id := range ids {
+			types = append(types, Object{"site_id": site.ID, "id": id, "name": server.saleFees[id].Name})
+		}
+		return http.StatusOK, types
+
+	case "listing_exposures":
+		return http.StatusOK, []Object{
+			{"id": "highest", "name": "Máxima", "home_page": true, "category_home_page": true, "advertising_on_listing_page": false, "priority_in_search": 0},
+			{"id": "lowest", "name": "Mínima", "home_page": false, "category_home_page": false, "advertising_on_listing_page": true, "priority_in_search": 4},
+		}
+
+	case "listing_prices":
+		price, err := strconv.ParseFloat(query.Get("price"), 64)
+		if err != nil {
+			return apiError(http.StatusBadRequest, "bad_request", "price is required")
+		}
+
+		if id := query.Get("listing_type_id"); id != "" {
+			fee, ok := server.saleFees[id]
+			if !ok {
+				return notFound()
+			}
+			return http.StatusOK, listingPrice(site, id, fee, price)
+		}
+
+		prices := []Object{}
+		for _, id := range ids {
+			prices = append(prices, listingPrice(site, id, server.saleFees[id], price))
+		}
+		return http.StatusOK, prices
+	}
+
+	return notFound()
+}
+
+func listingPrice(site sdk.Site, listingTypeID string, fee SaleFee, price float64) Object {
+
+	fixedFee := 0.0
+	if price < fee.FixedFeeThreshold {
+		fixedFee = fee.FixedFee
+	}
+
+	scale := math.Pow10(sdk.DecimalPlaces(site.CurrencyID))
+	amount := math.Round((price*fee.Percentage/100+fixedFee)*scale) / scale
+
+	return Object{
+		"listing_type_id":    listingTypeID,
+		"listing_type_name":  fee.Name,
+		"listing_exposure":   fee.Exposure,
+		"currency_id":        site.CurrencyID,
+		"sale_fee_amount":    amount,
+		"listing_fee_amount": fee.ListingFee,
+		"requires_picture":   listingTypeID != "free",
+		"sale_fee_details": Object{
+			"percentage_fee":       fee.Percentage,
+			"meli_percentage_fee":  fee.Percentage,
+			"fixed_fee":            fixedFee,
+			"gross_amount":         amount,
+			"financing_add_on_fee": 0,
+		},
+	}
+}
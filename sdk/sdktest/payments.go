@@ -0,0 +1,66 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdktest
+
+import (
+	"net/http"
+	"strconv"
+)
+
+/*
+AddPayment keeps the given payment. An id is assigned if it has none. Only its collector, set as collector_id,
+and its payer, set as payer.id, can read it.
+*/
+func (server *Server) AddPayment(payment Object) Object {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	payment = normalize(payment)
+
+	if _, ok := payment["id"]; !ok {
+		payment["id"], _ = strconv.ParseInt(server.nextID(), 10, 64)
+	}
+
+	server.payments[toInt64(payment["id"])] = payment
+	return payment
+}
+
+func (server *Server) routePayments(r *http.Request, segments []string) (int, interface{}) {
+
+	if r.Method != http.MethodGet || len(segments) != 3 || segments[1] != "payments" {
+		return notFound()
+	}
+
+	caller, authorized := server.caller(r)
+	if !authorized {
+		return unauthorized()
+	}
+
+	id, _ := strconv.ParseInt(segments[2], 10, 64)
+	payment, ok := server.payments[id]
+	if !ok {
+		return notFound()
+	}
+
+	payer, _ := payment["payer"].(map[string]interface{})
+	if toInt64(payment["collector_id"]) != caller && toInt64(payer["id"]) != caller {
+		return apiError(http.StatusForbidden, "forbidden", "the payment does not belong to the caller")
+	}
+
+	return http.StatusOK, payment
+}
@@ -0,0 +1,252 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdktest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+/*
+AddPromotion keeps a promotion available to the given seller, i.e. {"type": "DEAL", "name": "Black Friday"}.
+An id is assigned if it has none, and it is started unless told otherwise.
+*/
+func (server *Server) AddPromotion(sellerID int64, promotion Object) Object {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.addPromotion(sellerID, normalize(promotion))
+}
+
+/*
+AddPromotionCandidate makes an item a candidate of a promotion, i.e. {"id": "MLA1", "price": 100,
+"min_discounted_price": 70, "max_discounted_price": 95}
+*/
+func (server *Server) AddPromotionCandidate(promotionID string, item Object) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	item = normalize(item)
+	item["status"] = "candidate"
+
+	if server.promotionItems[promotionID] == nil {
+		server.promotionItems[promotionID] = make(map[string]Object)
+	}
+	server.promotionItems[promotionID][item["id"].(string)] = item
+}
+
+func (server *Server) addPromotion(sellerID int64, promotion Object) Object {
+
+	if _, ok := promotion["id"]; !ok {
+		promotion["id"] = "P-" + server.nextID()
+	}
+	if _, ok := promotion["status"]; !ok {
+		promotion["status"] = "started"
+	}
+	promotion["seller_id"] = sellerID
+
+	server.promotions[promotion["id"].(string)] = promotion
+	return promotion
+}
+
+func (server *Server) routePromotions(r *http.Request, segments []string, query url.Values, body []byte) (int, interface{}) {
+
+	caller, authorized := server.caller(r)
+	if !authorized {
+		return unauthorized()
+	}
+
+	if len(segments) < 3 {
+		return notFound()
+	}
+
+	switch {
+	case segments[1] == "users" && len(segments) == 3 && r.Method == http.MethodGet:
+
+		if toInt64(segments[2]) != caller {
+			return unauthorized()
+		}
+
+		results := []Object{}
+		for _, promotion := range server.promotions {
+			if toInt64(promotion["seller_id"]) == caller {
+				results = append(results, promotion)
+			}
+		}
+
+		sort.Slice(results, func(i, j int) bool { return results[i]["id"].(string) < results[j]["id"].(string) })
+
+		return http.StatusOK, Object{"results": results, "paging": Object{"total": len(results)}}
+
+	case segments[1] == "promotions" && len(segments) <= 4 && r.Method == http.MethodGet:
+
+		promotion, ok := server.promotions[segments[2]]
+		if !ok || toInt64(promotion["seller_id"]) != caller || promotion["type"] != query.Get("promotion_type") {
+			return notFound()
+		}
+
+		if len(segments) == 3 {
+			return http.StatusOK, promotion
+		}
+
+		if segments[3] != "items" {
+			return notFound()
+		}
+
+		results := []Object{}
+		for _, item := range server.promotionItems[segments[2]] {
+			if query.Get("status") == "" || item["status"] == query.Get("status") {
+				results = append(results, item)
+			}
+		}
+
+		sort.Slice(results, func(i, j int) bool { return results[i]["id"].(string) < results[j]["id"].(string) })
+
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		if limit <= 0 {
+			limit = searchLimit
+		}
+
+		return http.StatusOK, Object{"results": page(results, offset, limit), "paging": Object{"total": len(results), "offset": offset, "limit": limit}}
+
+	case segments[1] == "items" && len(segments) == 3:
+		return server.routeItemPromotions(r, caller, segments[2], query, body)
+	}
+
+	return notFound()
+}
+
+func (server *Server) routeItemPromotions(r *http.Request, caller int64, itemID string, query url.Values, body []byte) (int, interface{}) {
+
+	switch r.Method {
+	case http.MethodGet:
+
+		results := []Object{}
+		for promotionID, items := range server.promotionItems {
+
+			item, ok := items[itemID]
+			promotion := server.promotions[promotionID]
+
+			if ok && toInt64(promotion["seller_id"]) == caller {
+				results = append(results, Object{"id": promotionID, "type": promotion["type"], "name": promotion["name"],
+					"status": item["status"], "price": item["price"], "original_price": item["original_price"],
+					"start_date": promotion["start_date"], "finish_date": promotion["finish_date"]})
+			}
+		}
+
+		sort.Slice(results, func(i, j int) bool { return results[i]["id"].(string) < results[j]["id"].(string) })
+
+		return http.StatusOK, results
+
+	case http.MethodPost:
+
+		var offer struct {
+			PromotionID string  `json:"promotion_id"`
+			Type        string  `json:"promotion_type"`
+			DealPrice   float64 `json:"deal_price"`
+			StartDate   string  `json:"start_date"`
+			FinishDate  string  `json:"finish_date"`
+		}
+
+		if json.Unmarshal(body, &offer) != nil || offer.Type == "" || offer.DealPrice <= 0 {
+			return apiError(http.StatusBadRequest, "bad_request", "promotion_type and deal_price are required")
+		}
+
+		if offer.Type == "PRICE_DISCOUNT" && offer.PromotionID == "" {
+			return server.addPriceDiscount(caller, itemID, offer.DealPrice, offer.StartDate, offer.FinishDate)
+		}
+
+		item, ok := server.promotionItem(caller, offer.PromotionID, offer.Type, itemID)
+		if !ok {
+			return notFound()
+		}
+
+		if item["status"] != "candidate" {
+			return apiError(http.StatusBadRequest, "bad_request", "the item is not a candidate of the promotion")
+		}
+
+		if min := toFloat64(item["min_discounted_price"]); min > 0 && offer.DealPrice < min {
+			return apiError(http.StatusBadRequest, "bad_request", "deal_price is lower than min_discounted_price")
+		}
+		if max := toFloat64(item["max_discounted_price"]); max > 0 && offer.DealPrice > max {
+			return apiError(http.StatusBadRequest, "bad_request", "deal_price is greater than max_discounted_price")
+		}
+
+		item["original_price"] = item["price"]
+		item["price"] = offer.DealPrice
+		item["status"] = server.promotions[offer.PromotionID]["status"]
+
+		return http.StatusCreated, Object{"price": item["price"], "original_price": item["original_price"]}
+
+	case http.MethodDelete:
+
+		item, ok := server.promotionItem(caller, query.Get("promotion_id"), query.Get("promotion_type"), itemID)
+		if !ok || item["status"] == "candidate" {
+			return notFound()
+		}
+
+		item["price"] = item["original_price"]
+		delete(item, "original_price")
+		item["status"] = "candidate"
+
+		return http.StatusOK, Object{}
+	}
+
+	return notFound()
+}
+
+/*addPriceDiscount creates a PRICE_DISCOUNT promotion holding just the given item*/
+func (server *Server) addPriceDiscount(caller int64, itemID string, price float64, startDate string, finishDate string) (int, interface{}) {
+
+	listed, ok := server.items[itemID]
+	if !ok || toInt64(listed["seller_id"]) != caller {
+		return notFound()
+	}
+
+	promotion := Object{"type": "PRICE_DISCOUNT"}
+	if startDate != "" {
+		promotion["start_date"] = startDate
+	}
+	if finishDate != "" {
+		promotion["finish_date"] = finishDate
+	}
+
+	server.addPromotion(caller, promotion)
+
+	item := Object{"id": itemID, "status": "started", "price": price, "original_price": listed["price"]}
+	server.promotionItems[promotion["id"].(string)] = map[string]Object{itemID: item}
+
+	return http.StatusCreated, Object{"price": item["price"], "original_price": item["original_price"]}
+}
+
+/*promotionItem returns an item of a promotion of the caller with the given type*/
+func (server *Server) promotionItem(caller int64, promotionID string, promotionType string, itemID string) (Object, bool) {
+
+	promotion, ok := server.promotions[promotionID]
+	if !ok || toInt64(promotion["seller_id"]) != caller || promotion["type"] != promotionType {
+		return nil, false
+	}
+
+	item, ok := server.promotionItems[promotionID][itemID]
+	return item, ok
+}
@@ -0,0 +1,129 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdktest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+/*SetCategoryAttributes sets the attributes of a category, i.e. {"id": "BRAND", "tags": {"required": true}}*/
+func (server *Server) SetCategoryAttributes(categoryID string, attributes []Object) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	normalized := make([]interface{}, 0, len(attributes))
+	for _, attribute := range attributes {
+		normalized = append(normalized, map[string]interface{}(normalize(attribute)))
+	}
+
+	server.categories[categoryID] = normalized
+}
+
+/*
+SetItemHealth sets the health of an item, i.e. {"health": 0.5, "level": "basic", "goals": [...]}.
+The goals with a progress lower than 1 are returned as the actions to improve it.
+*/
+func (server *Server) SetItemHealth(itemID string, health Object) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	health = normalize(health)
+	health["item_id"] = itemID
+	server.health[itemID] = health
+}
+
+func (server *Server) routeCategories(segments []string) (int, interface{}) {
+
+	if len(segments) != 3 || segments[2] != "attributes" {
+		return notFound()
+	}
+
+	attributes, ok := server.categories[segments[1]]
+	if !ok {
+		return notFound()
+	}
+
+	return http.StatusOK, attributes
+}
+
+func (server *Server) routeHealth(itemID string, segments []string) (int, interface{}) {
+
+	health, ok := server.health[itemID]
+	if !ok {
+		return notFound()
+	}
+
+	if len(segments) == 0 {
+		return http.StatusOK, health
+	}
+
+	if segments[0] != "actions" {
+		return notFound()
+	}
+
+	actions := []Object{}
+	goals, _ := health["goals"].([]interface{})
+
+	for _, element := range goals {
+		if goal, ok := element.(map[string]interface{}); ok && goal["progress"] != float64(1) {
+			actions = append(actions, Object{"id": goal["id"], "name": goal["name"]})
+		}
+	}
+
+	return http.StatusOK, Object{"item_id": itemID, "actions": actions}
+}
+
+/*catalogQuality returns the required attributes of its category the item does not have*/
+func (server *Server) catalogQuality(query url.Values) (int, interface{}) {
+
+	itemID := query.Get("item_id")
+
+	item, ok := server.items[itemID]
+	if !ok {
+		return notFound()
+	}
+
+	present := map[string]bool{}
+	attributes, _ := item["attributes"].([]interface{})
+
+	for _, element := range attributes {
+		if attribute, ok := element.(map[string]interface{}); ok {
+			present[fmt.Sprint(attribute["id"])] = true
+		}
+	}
+
+	missing := []string{}
+	for _, element := range server.categories[fmt.Sprint(item["category_id"])] {
+
+		attribute, _ := element.(map[string]interface{})
+		tags, _ := attribute["tags"].(map[string]interface{})
+		id := fmt.Sprint(attribute["id"])
+
+		if (tags["required"] == true || tags["catalog_required"] == true) && !present[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	return http.StatusOK, Object{
+		"item_id":         itemID,
+		"adoption_status": Object{"all": Object{"complete": len(missing) == 0, "missing_attributes": missing}},
+	}
+}
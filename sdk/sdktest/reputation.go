@@ -0,0 +1,37 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdktest
+
+/*
+SetSellerReputation sets the reputation of a user as a seller, returned as its seller_reputation,
+i.e. {"level_id": "5_green", "metrics": {"claims": {"rate": 0.01}}}
+*/
+func (server *Server) SetSellerReputation(userID int64, reputation Object) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.reputations[userID] = normalize(reputation)
+}
+
+/*addSellerReputation adds the reputation of the given user, if it was set, to the user returned by the API*/
+func (server *Server) addSellerReputation(user Object, userID int64) {
+
+	if reputation, ok := server.reputations[userID]; ok {
+		user["seller_reputation"] = reputation
+	}
+}
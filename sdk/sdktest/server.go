@@ -0,0 +1,1034 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package sdktest provides a fake MercadoLibre API which runs in memory, so integrations built on top of
+the sdk package can be tested end to end without reaching the real API.
+
+The fake implements the OAuth token endpoint (authorization code and refresh token grants), users and their seller
+reputation, items with their variations, descriptions, health, visits and promotions, categories with their trends
+and best sellers, listing types and prices, currencies and conversions, orders with their payments, billing info,
+invoices and fiscal documents, questions, claims with their messages, attachments and returns, and Product Ads
+campaigns and metrics. Resources are plain JSON objects, so any field sent by the client is kept and returned.
+
+Usage:
+
+	server := sdktest.NewServer()
+	defer server.Close()
+
+	server.AddUser(sdktest.User{ID: 1234, Nickname: "SELLER"})
+	code := server.NewCode(1234)
+
+	client, err := sdk.NewClientManager(sdk.ManagerConfig{}).Client(server.Config(code))
+*/
+package sdktest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+)
+
+const (
+	ClientID     = 123456
+	ClientSecret = "sdktest secret"
+	CallBackURL  = "http://localhost/callback"
+)
+
+/*searchLimit is the page size of the item searches when no limit is given, as in the API*/
+const searchLimit = 50
+
+/*Object is a JSON object kept by the fake API*/
+type Object map[string]interface{}
+
+/*User is a MercadoLibre user known by the fake API*/
+type User struct {
+	ID       int64
+	Nickname string
+	SiteID   string
+	Email    string
+}
+
+/*File is a file uploaded to the fake API within a multipart form*/
+type File struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
+
+/*RecordedRequest is a request received by the fake API. Body is empty when the request had none.*/
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+/*
+Failure makes the fake API answer with the given status code and body instead of handling the request.
+An empty Method or Path matches any request, and Times is how many requests fail, or every one if it is zero.
+*/
+type Failure struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       string
+	Times      int
+}
+
+type token struct {
+	userID    int64
+	expiresAt time.Time
+}
+
+/*Server is a fake MercadoLibre API. Its zero value is not usable, use NewServer instead.*/
+type Server struct {
+	*httptest.Server
+
+	mutex           sync.Mutex
+	tokenTTL        time.Duration
+	sequence        int64
+	users           map[int64]User
+	codes           map[string]int64
+	accessTokens    map[string]token
+	refreshTokens   map[string]int64
+	items           map[string]Object
+	orders          map[int64]Object
+	questions       map[int64]Object
+	descriptions    map[string]Object
+	saleFees        map[string]SaleFee
+	categories      map[string][]interface{}
+	health          map[string]Object
+	visits          map[string]map[string]int
+	reputations     map[int64]Object
+	claims          map[int64]Object
+	claimMessages   map[int64][]interface{}
+	claimEvidences  map[int64][]interface{}
+	returns         map[int64]Object
+	attachments     map[int64]map[string]File
+	payments        map[int64]Object
+	billingInfo     map[int64]Object
+	invoices        map[int64]Object
+	fiscalDocuments map[int64]map[string]File
+	promotions      map[string]Object
+	promotionItems  map[string]map[string]Object
+	advertisers     map[int64]Object
+	campaigns       map[int64]Object
+	ads             map[string]Object
+	adMetrics       map[string]map[string]Object
+	trends          map[string][]interface{}
+	bestSellers     map[string][]interface{}
+	conversions     map[string]float64
+	failures        []*Failure
+	requests        []RecordedRequest
+}
+
+/*NewServer starts a fake MercadoLibre API. Close has to be called once the test ends.*/
+func NewServer() *Server {
+
+	server := &Server{
+		tokenTTL:        6 * time.Hour,
+		sequence:        1000,
+		users:           make(map[int64]User),
+		codes:           make(map[string]int64),
+		accessTokens:    make(map[string]token),
+		refreshTokens:   make(map[string]int64),
+		items:           make(map[string]Object),
+		orders:          make(map[int64]Object),
+		questions:       make(map[int64]Object),
+		descriptions:    make(map[string]Object),
+		saleFees:        defaultSaleFees(),
+		categories:      make(map[string][]interface{}),
+		health:          make(map[string]Object),
+		visits:          make(map[string]map[string]int),
+		reputations:     make(map[int64]Object),
+		claims:          make(map[int64]Object),
+		claimMessages:   make(map[int64][]interface{}),
+		claimEvidences:  make(map[int64][]interface{}),
+		returns:         make(map[int64]Object),
+		attachments:     make(map[int64]map[string]File),
+		payments:        make(map[int64]Object),
+		billingInfo:     make(map[int64]Object),
+		invoices:        make(map[int64]Object),
+		fiscalDocuments: make(map[int64]map[string]File),
+		promotions:      make(map[string]Object),
+		promotionItems:  make(map[string]map[string]Object),
+		advertisers:     make(map[int64]Object),
+		campaigns:       make(map[int64]Object),
+		ads:             make(map[string]Object),
+		adMetrics:       make(map[string]map[string]Object),
+		trends:          make(map[string][]interface{}),
+		bestSellers:     make(map[string][]interface{}),
+		conversions:     make(map[string]float64),
+	}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+
+	return server
+}
+
+/*Config returns a MeliConfig which points to this server and authorizes the given code*/
+func (server *Server) Config(code string) sdk.MeliConfig {
+	return sdk.MeliConfig{
+		ClientID:    ClientID,
+		UserCode:    code,
+		Secret:      ClientSecret,
+		CallBackURL: CallBackURL,
+		APIURL:      server.URL,
+	}
+}
+
+/*AddUser makes the user known by the fake API*/
+func (server *Server) AddUser(user User) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if user.SiteID == "" {
+		user.SiteID = sdk.SiteMLA
+	}
+
+	server.users[user.ID] = user
+}
+
+/*NewCode returns a code which can be exchanged once for the tokens of the given user*/
+func (server *Server) NewCode(userID int64) string {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	code := "TG-" + randomID() + "-" + strconv.FormatInt(userID, 10)
+	server.codes[code] = userID
+
+	return code
+}
+
+/*SetTokenTTL changes the expires_in of the tokens returned from now on. The SDK refreshes tokens expiring within a minute.*/
+func (server *Server) SetTokenTTL(ttl time.Duration) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.tokenTTL = ttl
+}
+
+/*ExpireTokens makes every access token issued so far invalid. Refresh tokens are still valid.*/
+func (server *Server) ExpireTokens() {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	for key, issued := range server.accessTokens {
+		issued.expiresAt = time.Time{}
+		server.accessTokens[key] = issued
+	}
+}
+
+/*Revoke invalidates every token of the given user, as if the user removed the application grant*/
+func (server *Server) Revoke(userID int64) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	for key, issued := range server.accessTokens {
+		if issued.userID == userID {
+			delete(server.accessTokens, key)
+		}
+	}
+
+	for key, owner := range server.refreshTokens {
+		if owner == userID {
+			delete(server.refreshTokens, key)
+		}
+	}
+}
+
+/*AddItem keeps the given item, which has to have an id, and returns it*/
+func (server *Server) AddItem(item Object) Object {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	//Items are kept as decoded JSON, so nested objects such as variations are handled the same whatever their Go type.
+	item = normalize(item)
+	server.items[fmt.Sprint(item["id"])] = item
+	return item
+}
+
+/*Item returns a copy of the item with the given id*/
+func (server *Server) Item(id string) (Object, bool) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	item, ok := server.items[id]
+	return copyObject(item), ok
+}
+
+/*AddOrder keeps the given order. An id is assigned if it has none. seller.id and buyer.id are used to authorize access.*/
+func (server *Server) AddOrder(order Object) Object {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if _, ok := order["id"]; !ok {
+		order["id"], _ = strconv.ParseInt(server.nextID(), 10, 64)
+	}
+
+	server.orders[toInt64(order["id"])] = order
+	return order
+}
+
+/*AddQuestion keeps the given question. An id is assigned if it has none.*/
+func (server *Server) AddQuestion(question Object) Object {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if _, ok := question["id"]; !ok {
+		question["id"], _ = strconv.ParseInt(server.nextID(), 10, 64)
+	}
+
+	if _, ok := question["status"]; !ok {
+		question["status"] = "UNANSWERED"
+	}
+
+	server.questions[toInt64(question["id"])] = question
+	return question
+}
+
+/*Question returns a copy of the question with the given id*/
+func (server *Server) Question(id int64) (Object, bool) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	question, ok := server.questions[id]
+	return copyObject(question), ok
+}
+
+/*Fail makes the matching requests fail, see Failure*/
+func (server *Server) Fail(failure Failure) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.failures = append(server.failures, &failure)
+}
+
+/*Requests returns the requests received so far*/
+func (server *Server) Requests() []RecordedRequest {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return append([]RecordedRequest(nil), server.requests...)
+}
+
+/*ResetRequests forgets the requests received so far*/
+func (server *Server) ResetRequests() {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.requests = nil
+}
+
+func (server *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+
+	body, _ := ioutil.ReadAll(r.Body)
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.requests = append(server.requests, RecordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	if failure := server.failure(r); failure != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(failure.StatusCode)
+		fmt.Fprint(w, failure.Body)
+		return
+	}
+
+	status, response := server.route(r, body)
+
+	if file, ok := response.(File); ok {
+		w.Header().Set("Content-Type", file.ContentType)
+		w.WriteHeader(status)
+		w.Write(file.Content)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (server *Server) failure(r *http.Request) *Failure {
+
+	for i, failure := range server.failures {
+
+		if (failure.Method != "" && failure.Method != r.Method) || (failure.Path != "" && failure.Path != r.URL.Path) {
+			continue
+		}
+
+		if failure.Times > 0 {
+			failure.Times--
+			if failure.Times == 0 {
+				server.failures = append(server.failures[:i], server.failures[i+1:]...)
+			}
+		}
+
+		return failure
+	}
+
+	return nil
+}
+
+func (server *Server) route(r *http.Request, body []byte) (int, interface{}) {
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	query := r.URL.Query()
+
+	switch {
+	case r.URL.Path == "/oauth/token" && r.Method == http.MethodPost:
+		return server.token(r, body)
+
+	case r.URL.Path == "/sites" && r.Method == http.MethodGet:
+		return server.sites()
+
+	case segments[0] == "sites" && r.Method == http.MethodGet:
+		return server.routeListings(segments, query)
+
+	case segments[0] == "categories" && r.Method == http.MethodGet:
+		return server.routeCategories(segments)
+
+	case r.URL.Path == "/catalog_quality/status" && r.Method == http.MethodGet:
+		return server.catalogQuality(query)
+
+	case segments[0] == "currencies" && r.Method == http.MethodGet:
+		return server.routeCurrencies(segments)
+
+	case r.URL.Path == "/currency_conversions/search" && r.Method == http.MethodGet:
+		return server.currencyConversion(query)
+
+	case segments[0] == "trends" && r.Method == http.MethodGet:
+		return server.routeTrends(segments)
+
+	case segments[0] == "highlights" && r.Method == http.MethodGet:
+		return server.routeHighlights(segments)
+
+	case r.URL.Path == "/visits/items" && r.Method == http.MethodGet:
+		return server.itemsVisits(query)
+
+	case segments[0] == "users":
+		return server.routeUsers(r, segments, query)
+
+	case segments[0] == "items":
+		return server.routeItems(r, segments, query, body)
+
+	case segments[0] == "orders":
+		return server.routeOrders(r, segments, query)
+
+	case segments[0] == "questions" || segments[0] == "answers":
+		return server.routeQuestions(r, segments, query, body)
+
+	case segments[0] == "v1" && len(segments) > 1 && segments[1] == "payments":
+		return server.routePayments(r, segments)
+
+	case segments[0] == "seller-promotions":
+		return server.routePromotions(r, segments, query, body)
+
+	case segments[0] == "advertising":
+		return server.routeAdvertising(r, segments, query)
+
+	case segments[0] == "packs":
+		return server.routePacks(r, segments, body)
+
+	case segments[0] == "post-purchase":
+		return server.routeClaims(r, segments, query, body)
+	}
+
+	return notFound()
+}
+
+/*
+token implements the authorization code and refresh token grants. Params can be sent either as query params,
+as the SDK does, or within a form encoded body.
+*/
+func (server *Server) token(r *http.Request, body []byte) (int, interface{}) {
+
+	params := r.URL.Query()
+	if form, err := url.ParseQuery(string(body)); err == nil {
+		for key, values := range form {
+			params[key] = values
+		}
+	}
+
+	if params.Get("client_id") != strconv.Itoa(ClientID) || params.Get("client_secret") != ClientSecret {
+		return apiError(http.StatusUnauthorized, "invalid_client", "invalid client_id or client_secret")
+	}
+
+	var userID int64
+	var ok bool
+
+	switch params.Get("grant_type") {
+	case sdk.AuthoricationCode:
+		userID, ok = server.codes[params.Get("code")]
+		delete(server.codes, params.Get("code"))
+	case sdk.RefreshToken:
+		userID, ok = server.refreshTokens[params.Get("refresh_token")]
+		delete(server.refreshTokens, params.Get("refresh_token"))
+	default:
+		return apiError(http.StatusBadRequest, "unsupported_grant_type", "unsupported grant type")
+	}
+
+	if !ok {
+		return apiError(http.StatusBadRequest, "invalid_grant", "Error validating grant. Your authorization code or refresh token may be expired or it was already used")
+	}
+
+	accessToken := "APP_USR-" + randomID() + "-" + strconv.FormatInt(userID, 10)
+	refreshToken := "TG-" + randomID() + "-" + strconv.FormatInt(userID, 10)
+
+	server.accessTokens[accessToken] = token{userID: userID, expiresAt: time.Now().Add(server.tokenTTL)}
+	server.refreshTokens[refreshToken] = userID
+
+	return http.StatusOK, Object{
+		"access_token":  accessToken,
+		"token_type":    "bearer",
+		"expires_in":    int64(server.tokenTTL / time.Second),
+		"scope":         "offline_access read write",
+		"user_id":       userID,
+		"refresh_token": refreshToken,
+	}
+}
+
+func (server *Server) sites() (int, interface{}) {
+
+	sites := []Object{}
+	for _, site := range sdk.DefaultSiteRegistry.Sites() {
+		sites = append(sites, Object{"id": site.ID, "name": site.Country, "default_currency_id": site.CurrencyID})
+	}
+
+	return http.StatusOK, sites
+}
+
+/*caller returns the user the access token of the request belongs to*/
+func (server *Server) caller(r *http.Request) (int64, bool) {
+
+	accessToken := r.URL.Query().Get("access_token")
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		accessToken = strings.TrimPrefix(header, "Bearer ")
+	}
+
+	issued, ok := server.accessTokens[accessToken]
+	if !ok || time.Now().After(issued.expiresAt) {
+		return 0, false
+	}
+
+	return issued.userID, true
+}
+
+func (server *Server) routeUsers(r *http.Request, segments []string, query url.Values) (int, interface{}) {
+
+	if r.Method != http.MethodGet || len(segments) < 2 {
+		return notFound()
+	}
+
+	caller, authorized := server.caller(r)
+
+	userID := caller
+	if segments[1] != "me" {
+		userID, _ = strconv.ParseInt(segments[1], 10, 64)
+	} else if !authorized {
+		return unauthorized()
+	}
+
+	user, ok := server.users[userID]
+	if !ok {
+		return notFound()
+	}
+
+	if len(segments) == 2 {
+
+		result := Object{"id": user.ID, "nickname": user.Nickname, "site_id": user.SiteID, "email": user.Email}
+		server.addSellerReputation(result, userID)
+
+		return http.StatusOK, result
+	}
+
+	if len(segments) == 5 && segments[2] == "invoices" && segments[3] == "orders" {
+
+		if !authorized || caller != userID {
+			return unauthorized()
+		}
+
+		orderID, _ := strconv.ParseInt(segments[4], 10, 64)
+		return server.invoiceOf(userID, orderID)
+	}
+
+	if len(segments) >= 3 && segments[2] == "items_visits" {
+
+		if !authorized || caller != userID {
+			return unauthorized()
+		}
+
+		return server.userVisits(userID, segments[3:], query)
+	}
+
+	if len(segments) == 4 && segments[2] == "items" && segments[3] == "search" {
+
+		if !authorized || caller != userID {
+			return unauthorized()
+		}
+
+		ids := []string{}
+		for id, item := range server.items {
+			if toInt64(item["seller_id"]) == userID && (query.Get("status") == "" || item["status"] == query.Get("status")) &&
+				(query.Get("seller_sku") == "" || hasSKU(item, query.Get("seller_sku"))) {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		if limit <= 0 {
+			limit = searchLimit
+		}
+
+		results := []string{}
+		if offset < len(ids) {
+			results = ids[offset:min(offset+limit, len(ids))]
+		}
+
+		return http.StatusOK, Object{"seller_id": userID, "results": results, "paging": Object{"total": len(ids), "offset": offset, "limit": limit}}
+	}
+
+	return notFound()
+}
+
+func (server *Server) routeItems(r *http.Request, segments []string, query url.Values, body []byte) (int, interface{}) {
+
+	caller, authorized := server.caller(r)
+
+	if len(segments) == 1 {
+
+		switch r.Method {
+		case http.MethodGet:
+			results := []Object{}
+			for _, id := range strings.Split(query.Get("ids"), ",") {
+				if item, ok := server.items[id]; ok {
+					results = append(results, Object{"code": http.StatusOK, "body": item})
+				} else {
+					results = append(results, Object{"code": http.StatusNotFound, "body": Object{"error": "not_found", "message": "Item with id " + id + " not found"}})
+				}
+			}
+			return http.StatusOK, results
+
+		case http.MethodPost:
+			if !authorized {
+				return unauthorized()
+			}
+
+			item := Object{}
+			if err := json.Unmarshal(body, &item); err != nil {
+				return apiError(http.StatusBadRequest, "bad_request", "body is not a JSON object")
+			}
+
+			item["id"] = server.users[caller].SiteID + server.nextID()
+			item["seller_id"] = caller
+			item["status"] = "active"
+			item["date_created"] = time.Now().UTC().Format(time.RFC3339)
+			server.items[fmt.Sprint(item["id"])] = item
+
+			return http.StatusCreated, item
+		}
+
+		return notFound()
+	}
+
+	item, ok := server.items[segments[1]]
+	if !ok {
+		return notFound()
+	}
+
+	if len(segments) > 2 {
+
+		if r.Method != http.MethodGet && (!authorized || toInt64(item["seller_id"]) != caller) {
+			return unauthorized()
+		}
+
+		switch {
+		case segments[2] == "variations" && len(segments) <= 4:
+			return server.routeVariations(r, item, segments[3:], body)
+		case segments[2] == "description" && len(segments) == 3:
+			return server.routeDescription(r, segments[1], body)
+		case segments[2] == "health" && r.Method == http.MethodGet && len(segments) <= 4:
+			return server.routeHealth(segments[1], segments[3:])
+		case segments[2] == "visits" && r.Method == http.MethodGet && len(segments) == 4 && segments[3] == "time_window":
+			return server.visitsTimeWindow(Object{"item_id": segments[1]}, []string{segments[1]}, query)
+		}
+
+		return notFound()
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return http.StatusOK, item
+
+	case http.MethodPut:
+		if !authorized || toInt64(item["seller_id"]) != caller {
+			return unauthorized()
+		}
+
+		changes := Object{}
+		if err := json.Unmarshal(body, &changes); err != nil {
+			return apiError(http.StatusBadRequest, "bad_request", "body is not a JSON object")
+		}
+
+		for key, value := range changes {
+			if key == "variations" {
+				item[key] = mergeVariations(item, value)
+			} else {
+				item[key] = value
+			}
+		}
+
+		return http.StatusOK, item
+
+	case http.MethodDelete:
+		if !authorized || toInt64(item["seller_id"]) != caller {
+			return unauthorized()
+		}
+
+		delete(server.items, segments[1])
+		delete(server.descriptions, segments[1])
+		return http.StatusOK, Object{}
+	}
+
+	return notFound()
+}
+
+func (server *Server) routeOrders(r *http.Request, segments []string, query url.Values) (int, interface{}) {
+
+	if r.Method != http.MethodGet || len(segments) < 2 || len(segments) > 3 || (len(segments) == 3 && segments[2] != "billing_info") {
+		return notFound()
+	}
+
+	caller, authorized := server.caller(r)
+	if !authorized {
+		return unauthorized()
+	}
+
+	if segments[1] == "search" && len(segments) == 2 {
+
+		if query.Get("seller") != strconv.FormatInt(caller, 10) {
+			return apiError(http.StatusForbidden, "forbidden", "seller param has to be the caller")
+		}
+
+		results := []Object{}
+		for _, order := range server.orders {
+			if sellerID(order) == caller && (query.Get("order.status") == "" || order["status"] == query.Get("order.status")) {
+				results = append(results, order)
+			}
+		}
+		sort.Slice(results, func(i, j int) bool { return toInt64(results[i]["id"]) < toInt64(results[j]["id"]) })
+
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		if limit <= 0 {
+			limit = searchLimit
+		}
+
+		return http.StatusOK, Object{"results": page(results, offset, limit), "paging": Object{"total": len(results), "offset": offset, "limit": limit}}
+	}
+
+	id, _ := strconv.ParseInt(segments[1], 10, 64)
+	order, ok := server.orders[id]
+	if !ok {
+		return notFound()
+	}
+
+	if sellerID(order) != caller && buyerID(order) != caller {
+		return apiError(http.StatusForbidden, "forbidden", "the order does not belong to the caller")
+	}
+
+	if len(segments) == 3 {
+		return server.billingInfoOf(order)
+	}
+
+	return http.StatusOK, order
+}
+
+func (server *Server) routeQuestions(r *http.Request, segments []string, query url.Values, body []byte) (int, interface{}) {
+
+	caller, authorized := server.caller(r)
+
+	switch {
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "questions" && segments[1] == "search":
+		questions := []Object{}
+		for _, question := range server.questions {
+			if fmt.Sprint(question["item_id"]) == query.Get("item") {
+				questions = append(questions, question)
+			}
+		}
+		return http.StatusOK, Object{"questions": questions, "total": len(questions)}
+
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "questions":
+		id, _ := strconv.ParseInt(segments[1], 10, 64)
+		if question, ok := server.questions[id]; ok {
+			return http.StatusOK, question
+		}
+		return notFound()
+
+	case r.Method == http.MethodPost && len(segments) == 1 && segments[0] == "questions":
+		if !authorized {
+			return unauthorized()
+		}
+
+		question := Object{}
+		if err := json.Unmarshal(body, &question); err != nil {
+			return apiError(http.StatusBadRequest, "bad_request", "body is not a JSON object")
+		}
+
+		item, ok := server.items[fmt.Sprint(question["item_id"])]
+		if !ok {
+			return notFound()
+		}
+
+		question["id"], _ = strconv.ParseInt(server.nextID(), 10, 64)
+		question["seller_id"] = item["seller_id"]
+		question["from"] = Object{"id": caller}
+		question["status"] = "UNANSWERED"
+		question["date_created"] = time.Now().UTC().Format(time.RFC3339)
+		server.questions[toInt64(question["id"])] = question
+
+		return http.StatusCreated, question
+
+	case r.Method == http.MethodPost && len(segments) == 1 && segments[0] == "answers":
+		if !authorized {
+			return unauthorized()
+		}
+
+		var answer struct {
+			QuestionID int64  `json:"question_id"`
+			Text       string `json:"text"`
+		}
+		if err := json.Unmarshal(body, &answer); err != nil {
+			return apiError(http.StatusBadRequest, "bad_request", "body is not a JSON object")
+		}
+
+		question, ok := server.questions[answer.QuestionID]
+		if !ok {
+			return notFound()
+		}
+
+		if toInt64(question["seller_id"]) != caller {
+			return apiError(http.StatusForbidden, "forbidden", "only the seller can answer the question")
+		}
+
+		question["status"] = "ANSWERED"
+		question["answer"] = Object{"text": answer.Text, "status": "ACTIVE", "date_created": time.Now().UTC().Format(time.RFC3339)}
+
+		return http.StatusOK, question
+	}
+
+	return notFound()
+}
+
+/*nextID returns a new unique id. The mutex has to be held.*/
+func (server *Server) nextID() string {
+	server.sequence++
+	return strconv.FormatInt(server.sequence, 10)
+}
+
+/*
+randomID returns a random hex string. Codes are random so they never collide across servers,
+as clients are kept by code within a sdk.ClientManager which may be shared across servers.
+*/
+func randomID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func sellerID(order Object) int64 {
+	seller, _ := order["seller"].(map[string]interface{})
+	if seller == nil {
+		seller, _ = order["seller"].(Object)
+	}
+	return toInt64(seller["id"])
+}
+
+func buyerID(order Object) int64 {
+	buyer, _ := order["buyer"].(map[string]interface{})
+	if buyer == nil {
+		buyer, _ = order["buyer"].(Object)
+	}
+	return toInt64(buyer["id"])
+}
+
+func copyObject(object Object) Object {
+
+	if object == nil {
+		return nil
+	}
+
+	copied := make(Object, len(object))
+	for key, value := range object {
+		copied[key] = value
+	}
+
+	return copied
+}
+
+/*normalize returns the object as it would be decoded from JSON*/
+func normalize(object Object) Object {
+
+	content, err := json.Marshal(object)
+	if err != nil {
+		return object
+	}
+
+	normalized := Object{}
+	if json.Unmarshal(content, &normalized) != nil {
+		return object
+	}
+
+	return normalized
+}
+
+/*formFile returns the file sent in the given field of a multipart form*/
+func formFile(r *http.Request, body []byte, field string) (File, error) {
+
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return File{}, fmt.Errorf("the body has to be multipart/form-data")
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return File{}, fmt.Errorf("the form has no %s file", field)
+		}
+
+		if part.FormName() != field || part.FileName() == "" {
+			continue
+		}
+
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			return File{}, err
+		}
+
+		return File{Name: part.FileName(), ContentType: part.Header.Get("Content-Type"), Content: content}, nil
+	}
+}
+
+/*sortByID sorts objects by their numeric id*/
+func sortByID(objects []Object) {
+	sort.Slice(objects, func(i, j int) bool { return toInt64(objects[i]["id"]) < toInt64(objects[j]["id"]) })
+}
+
+/*page returns the objects within the offset and limit. A zero limit means every object after the offset.*/
+func page(objects []Object, offset int, limit int) []Object {
+
+	if offset >= len(objects) {
+		return []Object{}
+	}
+
+	objects = objects[offset:]
+	if limit > 0 && limit < len(objects) {
+		objects = objects[:limit]
+	}
+
+	return objects
+}
+
+/*toInt64 converts ids which can come either from Go code or from decoded JSON*/
+func toInt64(value interface{}) int64 {
+
+	switch v := value.(type) {
+	case int:
+		return int64(v)
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	case json.Number:
+		n, _ := v.Int64()
+		return n
+	case string:
+		n, _ := strconv.ParseInt(v, 10, 64)
+		return n
+	}
+
+	return 0
+}
+
+/*toFloat64 converts amounts which can come either from Go code or from decoded JSON*/
+func toFloat64(value interface{}) float64 {
+
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	case json.Number:
+		n, _ := v.Float64()
+		return n
+	}
+
+	return 0
+}
+
+func apiError(status int, code string, message string) (int, interface{}) {
+	return status, Object{"message": message, "error": code, "status": status, "cause": []interface{}{}}
+}
+
+func notFound() (int, interface{}) {
+	return apiError(http.StatusNotFound, "not_found", "resource not found")
+}
+
+func unauthorized() (int, interface{}) {
+	return apiError(http.StatusUnauthorized, "unauthorized", "invalid access token")
+}
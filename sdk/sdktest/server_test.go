@@ -0,0 +1,231 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdktest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+)
+
+const testSellerID = 214509008
+
+func newTestServer() *Server {
+	server := NewServer()
+	server.AddUser(User{ID: testSellerID, Nickname: "TEST_SELLER"})
+	return server
+}
+
+func Test_Client_is_authorized_and_calls_private_API(t *testing.T) {
+
+	server := newTestServer()
+	defer server.Close()
+
+	client, err := sdk.NewClientManager(sdk.ManagerConfig{}).Client(server.Config(server.NewCode(testSellerID)))
+
+	if err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	if client.UserID() != testSellerID {
+		log.Printf("Error: expected user %d obtained %d", testSellerID, client.UserID())
+		t.FailNow()
+	}
+
+	resp, err := client.Get("/users/me")
+
+	if err != nil || resp.StatusCode != http.StatusOK {
+		log.Printf("Error: /users/me failed %v", err)
+		t.FailNow()
+	}
+
+	var me map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&me)
+
+	if me["nickname"] != "TEST_SELLER" {
+		log.Printf("Error: unexpected user %v", me)
+		t.FailNow()
+	}
+}
+
+func Test_Codes_can_be_exchanged_only_once(t *testing.T) {
+
+	server := newTestServer()
+	defer server.Close()
+
+	code := server.NewCode(testSellerID)
+
+	if _, err := sdk.NewClientManager(sdk.ManagerConfig{}).Client(server.Config(code)); err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	if _, err := sdk.NewClientManager(sdk.ManagerConfig{}).Client(server.Config(code)); err == nil {
+		log.Printf("Error: the code should have been rejected the second time")
+		t.FailNow()
+	}
+}
+
+func Test_Items_are_created_updated_and_deleted(t *testing.T) {
+
+	server := newTestServer()
+	defer server.Close()
+
+	client, _ := sdk.NewClientManager(sdk.ManagerConfig{}).Client(server.Config(server.NewCode(testSellerID)))
+
+	resp, err := client.Post("/items", "{\"title\":\"Item de test - No Ofertar\",\"price\":10}")
+
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		log.Printf("Error: item was not created %v", err)
+		t.FailNow()
+	}
+
+	var item map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&item)
+	id := item["id"].(string)
+
+	if resp, _ := client.Put("/items/"+id, "{\"price\":20}"); resp.StatusCode != http.StatusOK {
+		log.Printf("Error: item was not updated %d", resp.StatusCode)
+		t.FailNow()
+	}
+
+	if stored, _ := server.Item(id); stored["price"] != float64(20) || stored["title"] != "Item de test - No Ofertar" {
+		log.Printf("Error: unexpected item %v", stored)
+		t.FailNow()
+	}
+
+	if resp, _ := client.Delete("/items/" + id); resp.StatusCode != http.StatusOK {
+		log.Printf("Error: item was not deleted %d", resp.StatusCode)
+		t.FailNow()
+	}
+
+	if _, ok := server.Item(id); ok {
+		log.Printf("Error: item should have been deleted")
+		t.FailNow()
+	}
+}
+
+func Test_Token_is_refreshed_when_it_expires(t *testing.T) {
+
+	server := newTestServer()
+	defer server.Close()
+
+	//The SDK refreshes tokens expiring within a minute, so this one is refreshed on every call.
+	server.SetTokenTTL(30 * time.Second)
+
+	client, _ := sdk.NewClientManager(sdk.ManagerConfig{}).Client(server.Config(server.NewCode(testSellerID)))
+	client.Get("/users/me")
+	client.Get("/users/me")
+
+	refreshes := 0
+	for _, request := range server.Requests() {
+		if request.Path == "/oauth/token" && request.Query.Get("grant_type") == sdk.RefreshToken {
+			refreshes++
+		}
+	}
+
+	if refreshes != 2 {
+		log.Printf("Error: expected 2 refreshes obtained %d", refreshes)
+		t.FailNow()
+	}
+}
+
+func Test_Injected_failures_are_returned(t *testing.T) {
+
+	server := newTestServer()
+	defer server.Close()
+
+	client, _ := sdk.NewClientManager(sdk.ManagerConfig{}).Client(server.Config(server.NewCode(testSellerID)))
+
+	server.Fail(Failure{Method: http.MethodGet, Path: "/users/me", StatusCode: http.StatusServiceUnavailable, Body: "{\"message\":\"unavailable\"}", Times: 1})
+
+	resp, _ := client.Get("/users/me")
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusServiceUnavailable || !strings.Contains(string(body), "unavailable") {
+		log.Printf("Error: the injected failure was not returned")
+		t.FailNow()
+	}
+
+	if resp, _ := client.Get("/users/me"); resp.StatusCode != http.StatusOK {
+		log.Printf("Error: only one request should have failed")
+		t.FailNow()
+	}
+}
+
+func Test_Questions_are_asked_and_answered(t *testing.T) {
+
+	server := newTestServer()
+	defer server.Close()
+
+	server.AddUser(User{ID: 1, Nickname: "TEST_BUYER"})
+	server.AddItem(Object{"id": "MLA1", "seller_id": testSellerID, "title": "Item"})
+
+	buyer, _ := sdk.NewClientManager(sdk.ManagerConfig{}).Client(server.Config(server.NewCode(1)))
+	seller, _ := sdk.NewClientManager(sdk.ManagerConfig{}).Client(server.Config(server.NewCode(testSellerID)))
+
+	resp, _ := buyer.Post("/questions", "{\"item_id\":\"MLA1\",\"text\":\"Is it new?\"}")
+
+	var question map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&question)
+
+	answer, _ := json.Marshal(map[string]interface{}{"question_id": question["id"], "text": "Yes"})
+	resp, _ = seller.Post("/answers", string(answer))
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("Error: question was not answered %d", resp.StatusCode)
+		t.FailNow()
+	}
+
+	if resp, _ := buyer.Get("/orders/search?seller=1"); resp.StatusCode != http.StatusOK {
+		log.Printf("Error: orders search failed %d", resp.StatusCode)
+		t.FailNow()
+	}
+}
+
+func Test_Client_is_restored_from_a_stored_authorization(t *testing.T) {
+
+	server := newTestServer()
+	defer server.Close()
+
+	client, _ := sdk.NewClientManager(sdk.ManagerConfig{}).Client(server.Config(server.NewCode(testSellerID)))
+	stored := client.Authorization()
+
+	restored := sdk.RestoreClient(server.Config(""), stored)
+
+	if restored.UserID() != testSellerID {
+		log.Printf("Error: expected user %d obtained %d", testSellerID, restored.UserID())
+		t.FailNow()
+	}
+
+	if err := restored.RefreshAuthorization(); err != nil || restored.Authorization().AccessToken == stored.AccessToken {
+		log.Printf("Error: token was not refreshed %v", err)
+		t.FailNow()
+	}
+
+	if resp, err := restored.Get("/users/me"); err != nil || resp.StatusCode != http.StatusOK {
+		log.Printf("Error: restored client could not call the private API %v", err)
+		t.FailNow()
+	}
+}
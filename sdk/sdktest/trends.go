@@ -0,0 +1,114 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdktest
+
+import (
+	"net/http"
+	"strings"
+)
+
+/*SetTrends sets the trends of a site, or of one of its categories if categoryID is not empty, i.e. {"keyword": "celular"}*/
+func (server *Server) SetTrends(siteID string, categoryID string, trends []Object) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	normalized := make([]interface{}, 0, len(trends))
+	for _, trend := range trends {
+		normalized = append(normalized, map[string]interface{}(normalize(trend)))
+	}
+
+	server.trends[siteID+"/"+categoryID] = normalized
+}
+
+/*
+SetBestSellers sets the best sellers of a category, i.e. {"id": "MLA1", "type": "ITEM"}. They are returned in the given order,
+and their positions follow it unless they hold one.
+*/
+func (server *Server) SetBestSellers(siteID string, categoryID string, highlights []Object) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	normalized := make([]interface{}, 0, len(highlights))
+	for i, highlight := range highlights {
+		highlight = normalize(highlight)
+		if _, ok := highlight["position"]; !ok {
+			highlight["position"] = i + 1
+		}
+		normalized = append(normalized, map[string]interface{}(highlight))
+	}
+
+	server.bestSellers[siteID+"/"+categoryID] = normalized
+}
+
+func (server *Server) routeTrends(segments []string) (int, interface{}) {
+
+	if len(segments) < 2 || len(segments) > 3 {
+		return notFound()
+	}
+
+	categoryID := ""
+	if len(segments) == 3 {
+		categoryID = segments[2]
+	}
+
+	trends, ok := server.trends[segments[1]+"/"+categoryID]
+	if !ok {
+		return notFound()
+	}
+
+	return http.StatusOK, trends
+}
+
+func (server *Server) routeHighlights(segments []string) (int, interface{}) {
+
+	if len(segments) != 4 {
+		return notFound()
+	}
+
+	siteID := segments[1]
+
+	switch segments[2] {
+	case "category":
+		highlights, ok := server.bestSellers[siteID+"/"+segments[3]]
+		if !ok {
+			return notFound()
+		}
+
+		return http.StatusOK, Object{
+			"query_data": Object{"highlight_type": "BEST_SELLER", "criteria": "CATEGORY", "id": segments[3]},
+			"content":    highlights,
+		}
+
+	case "item":
+		for key, highlights := range server.bestSellers {
+			if !strings.HasPrefix(key, siteID+"/") {
+				continue
+			}
+
+			for _, value := range highlights {
+				highlight := value.(map[string]interface{})
+				if highlight["id"] == segments[3] && highlight["type"] == "ITEM" {
+					return http.StatusOK, highlight
+				}
+			}
+		}
+	}
+
+	return notFound()
+}
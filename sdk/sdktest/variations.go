@@ -0,0 +1,162 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+/*routeVariations handles the variations of an item, which are kept within its "variations" field*/
+func (server *Server) routeVariations(r *http.Request, item Object, segments []string, body []byte) (int, interface{}) {
+
+	variations, _ := item["variations"].([]interface{})
+
+	if len(segments) == 0 {
+
+		switch r.Method {
+		case http.MethodGet:
+			return http.StatusOK, variations
+
+		case http.MethodPost:
+			variation := Object{}
+			if err := json.Unmarshal(body, &variation); err != nil {
+				return apiError(http.StatusBadRequest, "bad_request", "body is not a JSON object")
+			}
+
+			variation["id"], _ = strconv.ParseInt(server.nextID(), 10, 64)
+			item["variations"] = append(variations, map[string]interface{}(variation))
+
+			return http.StatusCreated, item["variations"]
+		}
+
+		return notFound()
+	}
+
+	id, _ := strconv.ParseInt(segments[0], 10, 64)
+
+	for i, element := range variations {
+
+		variation, _ := element.(map[string]interface{})
+		if toInt64(variation["id"]) != id {
+			continue
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			return http.StatusOK, variation
+
+		case http.MethodPut:
+			changes := Object{}
+			if err := json.Unmarshal(body, &changes); err != nil {
+				return apiError(http.StatusBadRequest, "bad_request", "body is not a JSON object")
+			}
+
+			for key, value := range changes {
+				if key != "id" {
+					variation[key] = value
+				}
+			}
+
+			return http.StatusOK, variations
+
+		case http.MethodDelete:
+			item["variations"] = append(variations[:i:i], variations[i+1:]...)
+			return http.StatusOK, Object{}
+		}
+
+		return notFound()
+	}
+
+	return notFound()
+}
+
+/*
+mergeVariations applies the variations sent within an item update as the API does: the variations sent by id are
+changed, the ones without id are added and the ones not sent are deleted.
+*/
+func mergeVariations(item Object, value interface{}) []interface{} {
+
+	existing := map[int64]map[string]interface{}{}
+	if variations, ok := item["variations"].([]interface{}); ok {
+		for _, element := range variations {
+			if variation, ok := element.(map[string]interface{}); ok {
+				existing[toInt64(variation["id"])] = variation
+			}
+		}
+	}
+
+	merged := []interface{}{}
+	sent, _ := value.([]interface{})
+
+	for _, element := range sent {
+
+		changes, ok := element.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		variation, ok := existing[toInt64(changes["id"])]
+		if !ok {
+			variation = map[string]interface{}{}
+		}
+
+		for key, value := range changes {
+			variation[key] = value
+		}
+
+		merged = append(merged, variation)
+	}
+
+	return merged
+}
+
+/*hasSKU tells whether the item, or any of its variations, has the given seller SKU*/
+func hasSKU(item Object, sku string) bool {
+
+	if objectSKU(item) == sku {
+		return true
+	}
+
+	variations, _ := item["variations"].([]interface{})
+	for _, element := range variations {
+		if variation, ok := element.(map[string]interface{}); ok && objectSKU(variation) == sku {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*objectSKU returns the SELLER_SKU attribute of an item or variation, or its seller_custom_field*/
+func objectSKU(object map[string]interface{}) string {
+
+	attributes, _ := object["attributes"].([]interface{})
+	for _, element := range attributes {
+		if attribute, ok := element.(map[string]interface{}); ok && attribute["id"] == "SELLER_SKU" {
+			return fmt.Sprint(attribute["value_name"])
+		}
+	}
+
+	if sku, ok := object["seller_custom_field"].(string); ok {
+		return sku
+	}
+
+	return ""
+}
@@ -0,0 +1,141 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdktest
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dayLayout = "2006-01-02"
+
+/*AddVisits adds visits to an item on the given day, in UTC*/
+func (server *Server) AddVisits(itemID string, day time.Time, visits int) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	days, ok := server.visits[itemID]
+	if !ok {
+		days = make(map[string]int)
+		server.visits[itemID] = days
+	}
+
+	days[day.UTC().Format(dayLayout)] += visits
+}
+
+/*countVisits returns the visits of the given items between both days, both included. Zero days mean no limit.*/
+func (server *Server) countVisits(itemIDs []string, from string, to string) int {
+
+	total := 0
+
+	for _, itemID := range itemIDs {
+		for day, visits := range server.visits[itemID] {
+			if (from == "" || day >= from) && (to == "" || day <= to) {
+				total += visits
+			}
+		}
+	}
+
+	return total
+}
+
+/*itemsVisits returns the total visits of every item in the ids param*/
+func (server *Server) itemsVisits(query url.Values) (int, interface{}) {
+
+	from, to := day(query.Get("date_from")), day(query.Get("date_to"))
+
+	result := Object{}
+	for _, itemID := range strings.Split(query.Get("ids"), ",") {
+		if itemID != "" {
+			result[itemID] = server.countVisits([]string{itemID}, from, to)
+		}
+	}
+
+	return http.StatusOK, result
+}
+
+/*userVisits returns the visits of every item of the user, either in total or per day*/
+func (server *Server) userVisits(userID int64, segments []string, query url.Values) (int, interface{}) {
+
+	itemIDs := []string{}
+	for id, item := range server.items {
+		if toInt64(item["seller_id"]) == userID {
+			itemIDs = append(itemIDs, id)
+		}
+	}
+
+	if len(segments) == 1 && segments[0] == "time_window" {
+		return server.visitsTimeWindow(Object{"user_id": userID}, itemIDs, query)
+	}
+
+	if len(segments) != 0 {
+		return notFound()
+	}
+
+	from, to := day(query.Get("date_from")), day(query.Get("date_to"))
+
+	return http.StatusOK, Object{
+		"user_id":      userID,
+		"date_from":    query.Get("date_from"),
+		"date_to":      query.Get("date_to"),
+		"total_visits": server.countVisits(itemIDs, from, to),
+	}
+}
+
+/*visitsTimeWindow returns the visits of the given items per day, for the last days up to today*/
+func (server *Server) visitsTimeWindow(result Object, itemIDs []string, query url.Values) (int, interface{}) {
+
+	last, err := strconv.Atoi(query.Get("last"))
+	if err != nil || last <= 0 || query.Get("unit") != "day" {
+		return apiError(http.StatusBadRequest, "bad_request", "last and unit=day are required")
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	total := 0
+	results := []Object{}
+
+	for i := last - 1; i >= 0; i-- {
+		date := today.AddDate(0, 0, -i)
+		visits := server.countVisits(itemIDs, date.Format(dayLayout), date.Format(dayLayout))
+		total += visits
+		results = append(results, Object{"date": date.Format(time.RFC3339), "total": visits})
+	}
+
+	result["total_visits"] = total
+	result["last"] = last
+	result["unit"] = "day"
+	result["date_from"] = today.AddDate(0, 0, 1-last).Format(time.RFC3339)
+	result["date_to"] = today.Format(time.RFC3339)
+	result["results"] = results
+
+	return http.StatusOK, result
+}
+
+/*day returns the day of a date param, which can be either a date or a timestamp*/
+func day(param string) string {
+
+	if len(param) < len(dayLayout) {
+		return ""
+	}
+
+	return param[:len(dayLayout)]
+}
@@ -0,0 +1,182 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	SiteMLA = "MLA" // Argentina
+	SiteMLB = "MLB" // Brasil
+	SiteMCO = "MCO" // Colombia
+	SiteMCR = "MCR" // Costa Rica
+	SiteMEC = "MEC" // Ecuador
+	SiteMLC = "MLC" // Chile
+	SiteMLM = "MLM" // Mexico
+	SiteMLU = "MLU" // Uruguay
+	SiteMLV = "MLV" // Venezuela
+	SiteMPA = "MPA" // Panama
+	SiteMPE = "MPE" // Peru
+	SiteMPT = "MPT" // Portugal
+	SiteMRD = "MRD" // Dominicana
+)
+
+/*ErrUnknownSite is returned when a site id is not within the SiteRegistry*/
+var ErrUnknownSite = errors.New("unknown site")
+
+/*Site keeps the metadata of a MercadoLibre site*/
+type Site struct {
+	ID         string
+	Country    string
+	AuthURL    string
+	CurrencyID string
+	Locale     string
+	TimeZone   string
+}
+
+/*Location returns the time zone of the site*/
+func (site Site) Location() (*time.Location, error) {
+	return time.LoadLocation(site.TimeZone)
+}
+
+var knownSites = []Site{
+	{ID: SiteMLA, Country: "Argentina", AuthURL: AuthURLMLA, CurrencyID: "ARS", Locale: "es_AR", TimeZone: "America/Argentina/Buenos_Aires"},
+	{ID: SiteMLB, Country: "Brasil", AuthURL: AuthURLMLB, CurrencyID: "BRL", Locale: "pt_BR", TimeZone: "America/Sao_Paulo"},
+	{ID: SiteMCO, Country: "Colombia", AuthURL: AuthURLMco, CurrencyID: "COP", Locale: "es_CO", TimeZone: "America/Bogota"},
+	{ID: SiteMCR, Country: "Costa Rica", AuthURL: AuthURLMcr, CurrencyID: "CRC", Locale: "es_CR", TimeZone: "America/Costa_Rica"},
+	{ID: SiteMEC, Country: "Ecuador", AuthURL: AuthURLMec, CurrencyID: "USD", Locale: "es_EC", TimeZone: "America/Guayaquil"},
+	{ID: SiteMLC, Country: "Chile", AuthURL: AuthURLMlc, CurrencyID: "CLP", Locale: "es_CL", TimeZone: "America/Santiago"},
+	{ID: SiteMLM, Country: "Mexico", AuthURL: AuthURLMLM, CurrencyID: "MXN", Locale: "es_MX", TimeZone: "America/Mexico_City"},
+	{ID: SiteMLU, Country: "Uruguay", AuthURL: AuthURLMlu, CurrencyID: "UYU", Locale: "es_UY", TimeZone: "America/Montevideo"},
+	{ID: SiteMLV, Country: "Venezuela", AuthURL: AuthURLMlv, CurrencyID: "VES", Locale: "es_VE", TimeZone: "America/Caracas"},
+	{ID: SiteMPA, Country: "Panama", AuthURL: AuthURLMpa, CurrencyID: "USD", Locale: "es_PA", TimeZone: "America/Panama"},
+	{ID: SiteMPE, Country: "Peru", AuthURL: AuthURLMpe, CurrencyID: "PEN", Locale: "es_PE", TimeZone: "America/Lima"},
+	{ID: SiteMPT, Country: "Portugal", AuthURL: AuthURLMpt, CurrencyID: "EUR", Locale: "pt_PT", TimeZone: "Europe/Lisbon"},
+	{ID: SiteMRD, Country: "Dominicana", AuthURL: AuthURLMrd, CurrencyID: "DOP", Locale: "es_DO", TimeZone: "America/Santo_Domingo"},
+}
+
+/*
+DefaultSiteRegistry knows every site supported by the SDK. It is used by GetAuthURLForSite and LookupSite.
+*/
+var DefaultSiteRegistry = NewSiteRegistry(knownSites...)
+
+/*SiteRegistry keeps the sites by id. It is safe for concurrent use.*/
+type SiteRegistry struct {
+	mutex sync.RWMutex
+	sites map[string]Site
+}
+
+/*NewSiteRegistry returns a SiteRegistry holding the given sites*/
+func NewSiteRegistry(sites ...Site) *SiteRegistry {
+
+	registry := &SiteRegistry{sites: make(map[string]Site)}
+
+	for _, site := range sites {
+		registry.sites[site.ID] = site
+	}
+
+	return registry
+}
+
+/*Lookup returns the site with the given id*/
+func (registry *SiteRegistry) Lookup(siteID string) (Site, bool) {
+
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	site, ok := registry.sites[siteID]
+	return site, ok
+}
+
+/*Sites returns every site sorted by id*/
+func (registry *SiteRegistry) Sites() []Site {
+
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	sites := make([]Site, 0, len(registry.sites))
+	for _, site := range registry.sites {
+		sites = append(sites, site)
+	}
+
+	sort.Slice(sites, func(i, j int) bool { return sites[i].ID < sites[j].ID })
+
+	return sites
+}
+
+/*
+Refresh updates the registry with the sites returned by the /sites API.
+The country name and default currency of the known sites are updated, and new sites are added.
+Auth URL, locale and time zone are not returned by the API, so they are kept as they are.
+*/
+func (registry *SiteRegistry) Refresh(client *Client) error {
+
+	var sites []struct {
+		ID                string `json:"id"`
+		Name              string `json:"name"`
+		DefaultCurrencyID string `json:"default_currency_id"`
+	}
+
+	if err := client.getJSON("/sites", &sites); err != nil {
+		return err
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	for _, remote := range sites {
+
+		site := registry.sites[remote.ID]
+		site.ID = remote.ID
+
+		if remote.Name != "" {
+			site.Country = remote.Name
+		}
+
+		if remote.DefaultCurrencyID != "" {
+			site.CurrencyID = remote.DefaultCurrencyID
+		}
+
+		registry.sites[remote.ID] = site
+	}
+
+	return nil
+}
+
+/*LookupSite returns the site with the given id from the DefaultSiteRegistry*/
+func LookupSite(siteID string) (Site, bool) {
+	return DefaultSiteRegistry.Lookup(siteID)
+}
+
+/*
+GetAuthURLForSite returns the URL for the user to authenticate and authorize, using the auth URL
+of the given site, i.e. sdk.SiteMLB
+*/
+func GetAuthURLForSite(clientID int64, siteID string, callback string) (string, error) {
+
+	site, ok := LookupSite(siteID)
+
+	if !ok || site.AuthURL == "" {
+		return "", ErrUnknownSite
+	}
+
+	return GetAuthURL(clientID, site.AuthURL, callback), nil
+}
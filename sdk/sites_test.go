@@ -0,0 +1,76 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"log"
+	"testing"
+)
+
+func Test_URL_for_authentication_is_built_from_the_site_id(t *testing.T) {
+
+	url, err := GetAuthURLForSite(CLIENT_ID, SiteMLB, "http://someurl.com")
+
+	if err != nil || url != GetAuthURL(CLIENT_ID, AuthURLMLB, "http://someurl.com") {
+		log.Printf("Error: unexpected url %s %v", url, err)
+		t.FailNow()
+	}
+
+	if _, err := GetAuthURLForSite(CLIENT_ID, "XXX", "http://someurl.com"); err != ErrUnknownSite {
+		log.Printf("Error: ErrUnknownSite was expected")
+		t.FailNow()
+	}
+}
+
+func Test_Sites_have_currency_locale_and_time_zone(t *testing.T) {
+
+	for _, site := range DefaultSiteRegistry.Sites() {
+
+		if site.AuthURL == "" || site.CurrencyID == "" || site.Locale == "" {
+			log.Printf("Error: site %s is incomplete", site.ID)
+			t.FailNow()
+		}
+
+		if _, err := site.Location(); err != nil {
+			log.Printf("Error: site %s has an invalid time zone %s", site.ID, err)
+			t.FailNow()
+		}
+	}
+}
+
+func Test_SiteRegistry_is_refreshed_from_the_sites_API(t *testing.T) {
+
+	client, _ := newTestAnonymousClient(API_TEST)
+	registry := NewSiteRegistry(Site{ID: SiteMPE, Country: "Peru", AuthURL: AuthURLMpe, CurrencyID: "PEN"})
+
+	if err := registry.Refresh(client); err != nil {
+		log.Printf("Error: %s", err)
+		t.FailNow()
+	}
+
+	site, ok := registry.Lookup(SiteMPE)
+
+	if !ok || site.Country != "Perú" || site.AuthURL != AuthURLMpe || site.CurrencyID != "PEN" {
+		log.Printf("Error: site was not properly refreshed %+v", site)
+		t.FailNow()
+	}
+
+	if len(registry.Sites()) != 13 {
+		log.Printf("Error: expected 13 sites obtained %d", len(registry.Sites()))
+		t.FailNow()
+	}
+}
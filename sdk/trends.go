@@ -0,0 +1,123 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"net/url"
+	"sort"
+)
+
+/*The kinds of highlighted results*/
+const (
+	HighlightItem        = "ITEM"
+	HighlightProduct     = "PRODUCT"
+	HighlightUserProduct = "USER_PRODUCT"
+)
+
+/*Trend is a keyword buyers are searching for, most searched first*/
+type Trend struct {
+	Keyword string `json:"keyword"`
+	URL     string `json:"url"`
+}
+
+/*TrendsDiff are the trends which appeared and disappeared between two snapshots*/
+type TrendsDiff struct {
+	New     []Trend
+	Dropped []Trend
+}
+
+/*Changed tells whether any trend appeared or disappeared*/
+func (diff TrendsDiff) Changed() bool {
+	return len(diff.New) > 0 || len(diff.Dropped) > 0
+}
+
+/*DiffTrends compares two snapshots of trends by keyword. New trends keep the order of after, and dropped ones the order of before.*/
+func DiffTrends(before []Trend, after []Trend) TrendsDiff {
+
+	var diff TrendsDiff
+
+	previous := make(map[string]bool, len(before))
+	for _, trend := range before {
+		previous[trend.Keyword] = true
+	}
+
+	current := make(map[string]bool, len(after))
+	for _, trend := range after {
+		current[trend.Keyword] = true
+		if !previous[trend.Keyword] {
+			diff.New = append(diff.New, trend)
+		}
+	}
+
+	for _, trend := range before {
+		if !current[trend.Keyword] {
+			diff.Dropped = append(diff.Dropped, trend)
+		}
+	}
+
+	return diff
+}
+
+/*Highlight is a best seller of a category, ranked by position starting at 1*/
+type Highlight struct {
+	ID       string `json:"id"`
+	Position int    `json:"position"`
+	Type     string `json:"type"`
+}
+
+/*GetTrends returns the trends of a site or, if categoryID is not empty, of one of its categories*/
+func (client *Client) GetTrends(siteID string, categoryID string) ([]Trend, error) {
+
+	resource := "/trends/" + url.PathEscape(siteID)
+	if categoryID != "" {
+		resource += "/" + url.PathEscape(categoryID)
+	}
+
+	var trends []Trend
+	if err := client.getJSON(resource, &trends); err != nil {
+		return nil, err
+	}
+
+	return trends, nil
+}
+
+/*GetBestSellers returns the best sellers of a category, either items or catalog products, sorted by position*/
+func (client *Client) GetBestSellers(siteID string, categoryID string) ([]Highlight, error) {
+
+	var result struct {
+		Content []Highlight `json:"content"`
+	}
+
+	if err := client.getJSON("/highlights/"+url.PathEscape(siteID)+"/category/"+url.PathEscape(categoryID), &result); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(result.Content, func(i, j int) bool { return result.Content[i].Position < result.Content[j].Position })
+
+	return result.Content, nil
+}
+
+/*GetItemHighlight returns the position of an item among the best sellers of its category. It fails with 404 if it is not one.*/
+func (client *Client) GetItemHighlight(siteID string, itemID string) (*Highlight, error) {
+
+	highlight := new(Highlight)
+	if err := client.getJSON("/highlights/"+url.PathEscape(siteID)+"/item/"+url.PathEscape(itemID), highlight); err != nil {
+		return nil, err
+	}
+
+	return highlight, nil
+}
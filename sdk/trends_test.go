@@ -0,0 +1,96 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"log"
+	"testing"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+func Test_Trends_and_best_sellers_are_read(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.SetTrends(sdk.SiteMLA, "", []sdktest.Object{{"keyword": "celular", "url": "https://listado.mercadolibre.com.ar/celular"}, {"keyword": "zapatillas"}})
+	server.SetTrends(sdk.SiteMLA, "MLA1055", []sdktest.Object{{"keyword": "iphone"}})
+	server.SetBestSellers(sdk.SiteMLA, "MLA1055", []sdktest.Object{{"id": "MLA1", "type": "ITEM"}, {"id": "MLA123P", "type": "PRODUCT"}, {"id": "MLA2", "type": "ITEM"}})
+
+	trends, err := client.GetTrends(sdk.SiteMLA, "")
+
+	if err != nil || len(trends) != 2 || trends[0].Keyword != "celular" || trends[0].URL == "" {
+		log.Printf("Error: unexpected trends %+v %v", trends, err)
+		t.FailNow()
+	}
+
+	trends, err = client.GetTrends(sdk.SiteMLA, "MLA1055")
+
+	if err != nil || len(trends) != 1 || trends[0].Keyword != "iphone" {
+		log.Printf("Error: unexpected category trends %+v %v", trends, err)
+		t.FailNow()
+	}
+
+	bestSellers, err := client.GetBestSellers(sdk.SiteMLA, "MLA1055")
+
+	if err != nil || len(bestSellers) != 3 || bestSellers[1].Type != sdk.HighlightProduct || bestSellers[2].Position != 3 {
+		log.Printf("Error: unexpected best sellers %+v %v", bestSellers, err)
+		t.FailNow()
+	}
+
+	highlight, err := client.GetItemHighlight(sdk.SiteMLA, "MLA2")
+
+	if err != nil || highlight.Position != 3 || highlight.Type != sdk.HighlightItem {
+		log.Printf("Error: unexpected highlight %+v %v", highlight, err)
+		t.FailNow()
+	}
+
+	if _, err := client.GetItemHighlight(sdk.SiteMLA, "MLA3"); err == nil {
+		log.Printf("Error: an item which is not a best seller was highlighted")
+		t.FailNow()
+	}
+
+	server.SetBestSellers(sdk.SiteMLA, "MLA1051", []sdktest.Object{{"id": "MLA3", "type": "ITEM", "position": 2}, {"id": "MLA4", "type": "ITEM", "position": 1}})
+
+	bestSellers, err = client.GetBestSellers(sdk.SiteMLA, "MLA1051")
+
+	if err != nil || len(bestSellers) != 2 || bestSellers[0].ID != "MLA4" || bestSellers[1].Position != 2 {
+		log.Printf("Error: best sellers are not sorted by position %+v %v", bestSellers, err)
+		t.FailNow()
+	}
+}
+
+func Test_Trends_diff_reports_new_and_dropped_keywords(t *testing.T) {
+
+	before := []sdk.Trend{{Keyword: "celular"}, {Keyword: "zapatillas"}, {Keyword: "notebook"}}
+	after := []sdk.Trend{{Keyword: "notebook"}, {Keyword: "aire acondicionado"}, {Keyword: "celular"}, {Keyword: "ventilador"}}
+
+	diff := sdk.DiffTrends(before, after)
+
+	if !diff.Changed() || len(diff.New) != 2 || diff.New[0].Keyword != "aire acondicionado" || diff.New[1].Keyword != "ventilador" ||
+		len(diff.Dropped) != 1 || diff.Dropped[0].Keyword != "zapatillas" {
+		log.Printf("Error: unexpected diff %+v", diff)
+		t.FailNow()
+	}
+
+	if sdk.DiffTrends(after, after).Changed() {
+		log.Printf("Error: the same snapshot should not change")
+		t.FailNow()
+	}
+}
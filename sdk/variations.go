@@ -0,0 +1,288 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+/*SellerSKUAttribute is the id of the attribute keeping the SKU the seller gives to an item or variation*/
+const SellerSKUAttribute = "SELLER_SKU"
+
+/*ErrSKUNotFound is returned when no item or variation has the given SKU*/
+var ErrSKUNotFound = errors.New("SKU not found")
+
+/*Attribute is a characteristic of an item or variation, i.e. its color or SKU*/
+type Attribute struct {
+	ID        string `json:"id"`
+	Name      string `json:"name,omitempty"`
+	ValueID   string `json:"value_id,omitempty"`
+	ValueName string `json:"value_name,omitempty"`
+}
+
+/*
+Variation is one of the versions of an item, i.e. its color and size, with its own stock.
+AvailableQuantity is always sent, as zero is a valid stock. The API does not return the currency of a variation,
+so its Price is in the currency of the item when read within it, and of no currency when read on its own.
+*/
+type Variation struct {
+	ID                    int64       `json:"id,omitempty"`
+	Price                 Money       `json:"price,omitempty"`
+	AvailableQuantity     int         `json:"available_quantity"`
+	SoldQuantity          int         `json:"sold_quantity,omitempty"`
+	AttributeCombinations []Attribute `json:"attribute_combinations,omitempty"`
+	Attributes            []Attribute `json:"attributes,omitempty"`
+	SellerCustomField     string      `json:"seller_custom_field,omitempty"`
+	PictureIDs            []string    `json:"picture_ids,omitempty"`
+}
+
+/*MarshalJSON encodes the variation as the API does, with its price as a plain number*/
+func (variation Variation) MarshalJSON() ([]byte, error) {
+
+	type plain Variation
+	encoded := struct {
+		plain
+		Price json.Number `json:"price,omitempty"`
+	}{plain: plain(variation)}
+
+	if !variation.Price.IsZero() {
+		encoded.Price = json.Number(variation.Price.Amount())
+	}
+
+	return json.Marshal(encoded)
+}
+
+/*UnmarshalJSON decodes the price of the variation exactly, without a currency as the API does not return it*/
+func (variation *Variation) UnmarshalJSON(data []byte) error {
+
+	type plain Variation
+	var decoded struct {
+		plain
+		Price json.Number `json:"price"`
+	}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	price, err := decodeAmount(decoded.Price, "")
+	if err != nil {
+		return err
+	}
+
+	*variation = Variation(decoded.plain)
+	variation.Price = price
+
+	return nil
+}
+
+/*SKU returns the SELLER_SKU attribute of the variation, or its seller_custom_field when it has none*/
+func (variation Variation) SKU() string {
+	return sku(variation.Attributes, variation.SellerCustomField)
+}
+
+/*SKU returns the SELLER_SKU attribute of the item, or its seller_custom_field when it has none*/
+func (item Item) SKU() string {
+	return sku(item.Attributes, item.SellerCustomField)
+}
+
+func sku(attributes []Attribute, sellerCustomField string) string {
+
+	for _, attribute := range attributes {
+		if attribute.ID == SellerSKUAttribute {
+			return attribute.ValueName
+		}
+	}
+
+	return sellerCustomField
+}
+
+/*SKUMatch is an item, and the variation if any, having a given SKU*/
+type SKUMatch struct {
+	Item      *Item
+	Variation *Variation
+}
+
+func variationsPath(itemID string) string {
+	return "/items/" + url.PathEscape(itemID) + "/variations"
+}
+
+/*GetVariations returns the variations of an item*/
+func (client *Client) GetVariations(itemID string) ([]Variation, error) {
+
+	var variations []Variation
+	if err := client.getJSON(variationsPath(itemID), &variations); err != nil {
+		return nil, err
+	}
+
+	return variations, nil
+}
+
+/*GetVariation returns a variation of an item*/
+func (client *Client) GetVariation(itemID string, variationID int64) (*Variation, error) {
+
+	variation := new(Variation)
+	if err := client.getJSON(variationsPath(itemID)+"/"+strconv.FormatInt(variationID, 10), variation); err != nil {
+		return nil, err
+	}
+
+	return variation, nil
+}
+
+/*AddVariation adds a variation to an item and returns every variation of the item*/
+func (client *Client) AddVariation(itemID string, variation Variation) ([]Variation, error) {
+
+	variation.ID = 0
+
+	var variations []Variation
+	if err := client.postJSON(variationsPath(itemID), variation, &variations); err != nil {
+		return nil, err
+	}
+
+	return variations, nil
+}
+
+/*
+UpdateVariation applies the given changes to a variation and returns every variation of the item.
+changes is encoded as JSON, so a map with only the fields to be changed can be used.
+*/
+func (client *Client) UpdateVariation(itemID string, variationID int64, changes interface{}) ([]Variation, error) {
+
+	var variations []Variation
+	if err := client.putJSON(variationsPath(itemID)+"/"+strconv.FormatInt(variationID, 10), changes, &variations); err != nil {
+		return nil, err
+	}
+
+	return variations, nil
+}
+
+/*DeleteVariation deletes a variation of an item*/
+func (client *Client) DeleteVariation(itemID string, variationID int64) error {
+
+	resp, err := client.Delete(variationsPath(itemID) + "/" + strconv.FormatInt(variationID, 10))
+	if err != nil {
+		return err
+	}
+
+	return decodeResponse(resp, nil)
+}
+
+/*
+SetStockBySKU sets the available quantity of the variation of an item with the given SKU, or of the item itself
+when it has no variations. The API deletes the variations not sent within an item update, so every variation
+is sent by id along with the new stock. ErrSKUNotFound is returned if nothing within the item has the SKU.
+*/
+func (client *Client) SetStockBySKU(itemID string, sku string, quantity int) (*Item, error) {
+
+	item, err := client.getItemWithAttributes(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(item.Variations) == 0 {
+
+		if item.SKU() != sku {
+			return nil, ErrSKUNotFound
+		}
+
+		return client.UpdateItem(itemID, map[string]interface{}{"available_quantity": quantity})
+	}
+
+	type variationStock struct {
+		ID                int64 `json:"id"`
+		AvailableQuantity int   `json:"available_quantity"`
+	}
+
+	found := false
+	variations := make([]variationStock, 0, len(item.Variations))
+
+	for _, variation := range item.Variations {
+
+		stock := variationStock{ID: variation.ID, AvailableQuantity: variation.AvailableQuantity}
+
+		if variation.SKU() == sku {
+			stock.AvailableQuantity = quantity
+			found = true
+		}
+
+		variations = append(variations, stock)
+	}
+
+	if !found {
+		return nil, ErrSKUNotFound
+	}
+
+	return client.UpdateItem(itemID, map[string]interface{}{"variations": variations})
+}
+
+/*
+FindBySKU returns the items, and their variations, with the given SKU among the items of the user the client
+acts on behalf of. ErrSKUNotFound is returned when there is none.
+*/
+func (client *Client) FindBySKU(sku string) ([]SKUMatch, error) {
+
+	itemIDs, err := client.searchSKU(sku)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []SKUMatch
+
+	for _, itemID := range itemIDs {
+
+		item, err := client.getItemWithAttributes(itemID)
+		if err != nil {
+			return nil, err
+		}
+
+		if item.SKU() == sku {
+			matches = append(matches, SKUMatch{Item: item})
+		}
+
+		for i := range item.Variations {
+			if item.Variations[i].SKU() == sku {
+				matches = append(matches, SKUMatch{Item: item, Variation: &item.Variations[i]})
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, ErrSKUNotFound
+	}
+
+	return matches, nil
+}
+
+/*searchSKU returns the ids of every item of the user with the given SKU, reading one page after the other*/
+func (client *Client) searchSKU(sku string) ([]string, error) {
+
+	return client.searchAllItemIDs(url.Values{"seller_sku": {sku}})
+}
+
+/*getItemWithAttributes returns an item along with the attributes of its variations, which keep their SKU*/
+func (client *Client) getItemWithAttributes(itemID string) (*Item, error) {
+
+	item := new(Item)
+	if err := client.getJSON("/items/"+url.PathEscape(itemID)+"?include_attributes=all", item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
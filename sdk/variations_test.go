@@ -0,0 +1,167 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+func addItemWithVariations(server *sdktest.Server) {
+
+	server.AddItem(sdktest.Object{
+		"id":        "MLA1",
+		"seller_id": testSellerID,
+		"title":     "Remera",
+		"variations": []sdktest.Object{
+			{
+				"id":                     101,
+				"available_quantity":     5,
+				"attribute_combinations": []sdktest.Object{{"id": "COLOR", "value_name": "Rojo"}},
+				"attributes":             []sdktest.Object{{"id": "SELLER_SKU", "value_name": "REM-ROJO"}},
+			},
+			{
+				"id":                     102,
+				"available_quantity":     7,
+				"attribute_combinations": []sdktest.Object{{"id": "COLOR", "value_name": "Azul"}},
+				"seller_custom_field":    "REM-AZUL",
+			},
+		},
+	})
+
+	server.AddItem(sdktest.Object{"id": "MLA2", "seller_id": testSellerID, "title": "Gorra", "available_quantity": 3, "seller_custom_field": "GORRA"})
+}
+
+func Test_Variations_are_listed_added_updated_and_deleted(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+	addItemWithVariations(server)
+
+	variations, err := client.GetVariations("MLA1")
+
+	if err != nil || len(variations) != 2 || variations[0].SKU() != "REM-ROJO" || variations[1].SKU() != "REM-AZUL" {
+		log.Printf("Error: unexpected variations %+v %v", variations, err)
+		t.FailNow()
+	}
+
+	variations, err = client.AddVariation("MLA1", sdk.Variation{
+		AvailableQuantity:     0,
+		AttributeCombinations: []sdk.Attribute{{ID: "COLOR", ValueName: "Verde"}},
+		Attributes:            []sdk.Attribute{{ID: sdk.SellerSKUAttribute, ValueName: "REM-VERDE"}},
+	})
+
+	if err != nil || len(variations) != 3 || variations[2].ID == 0 {
+		log.Printf("Error: variation was not added %+v %v", variations, err)
+		t.FailNow()
+	}
+
+	added := variations[2].ID
+
+	if _, err := client.UpdateVariation("MLA1", added, map[string]interface{}{"available_quantity": 4}); err != nil {
+		log.Printf("Error: variation was not updated %v", err)
+		t.FailNow()
+	}
+
+	if variation, err := client.GetVariation("MLA1", added); err != nil || variation.AvailableQuantity != 4 {
+		log.Printf("Error: unexpected variation %+v %v", variation, err)
+		t.FailNow()
+	}
+
+	if err := client.DeleteVariation("MLA1", added); err != nil {
+		log.Printf("Error: variation was not deleted %v", err)
+		t.FailNow()
+	}
+
+	if variations, _ := client.GetVariations("MLA1"); len(variations) != 2 {
+		log.Printf("Error: expected 2 variations obtained %d", len(variations))
+		t.FailNow()
+	}
+}
+
+func Test_Stock_is_set_by_SKU_keeping_the_other_variations(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+	addItemWithVariations(server)
+
+	item, err := client.SetStockBySKU("MLA1", "REM-AZUL", 0)
+
+	if err != nil || len(item.Variations) != 2 || item.Variations[0].AvailableQuantity != 5 || item.Variations[1].AvailableQuantity != 0 {
+		log.Printf("Error: unexpected variations after the update %+v %v", item, err)
+		t.FailNow()
+	}
+
+	if item.Variations[0].AttributeCombinations[0].ValueName != "Rojo" {
+		log.Printf("Error: the other variation was changed %+v", item.Variations[0])
+		t.FailNow()
+	}
+
+	if item, err := client.SetStockBySKU("MLA2", "GORRA", 10); err != nil || item.AvailableQuantity != 10 {
+		log.Printf("Error: item stock was not set %+v %v", item, err)
+		t.FailNow()
+	}
+
+	if _, err := client.SetStockBySKU("MLA1", "UNKNOWN", 1); !errors.Is(err, sdk.ErrSKUNotFound) {
+		log.Printf("Error: expected ErrSKUNotFound obtained %v", err)
+		t.FailNow()
+	}
+}
+
+func Test_Items_are_found_by_SKU(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+	addItemWithVariations(server)
+
+	matches, err := client.FindBySKU("REM-ROJO")
+
+	if err != nil || len(matches) != 1 || matches[0].Item.ID != "MLA1" || matches[0].Variation == nil || matches[0].Variation.ID != 101 {
+		log.Printf("Error: unexpected matches %+v %v", matches, err)
+		t.FailNow()
+	}
+
+	if matches, err := client.FindBySKU("GORRA"); err != nil || len(matches) != 1 || matches[0].Variation != nil {
+		log.Printf("Error: unexpected matches %+v %v", matches, err)
+		t.FailNow()
+	}
+
+	if _, err := client.FindBySKU("UNKNOWN"); !errors.Is(err, sdk.ErrSKUNotFound) {
+		log.Printf("Error: expected ErrSKUNotFound obtained %v", err)
+		t.FailNow()
+	}
+}
+
+func Test_Items_found_by_SKU_are_read_from_every_page_of_the_search(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	for i := 1; i <= 60; i++ {
+		server.AddItem(sdktest.Object{"id": fmt.Sprintf("MLA%d", i), "seller_id": testSellerID, "seller_custom_field": "SAME-SKU"})
+	}
+
+	if matches, err := client.FindBySKU("SAME-SKU"); err != nil || len(matches) != 60 {
+		log.Printf("Error: expected 60 matches obtained %d %v", len(matches), err)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*maxVisitsItems is how many items the /visits/items API accepts at once*/
+const maxVisitsItems = 50
+
+/*TimeWindow is a period ending now, i.e. the last 30 days*/
+type TimeWindow struct {
+	Last int
+	Unit string
+}
+
+/*LastDays returns the time window of the last n days, today included, broken down per day*/
+func LastDays(n int) TimeWindow {
+	return TimeWindow{Last: n, Unit: "day"}
+}
+
+func (window TimeWindow) query() string {
+	return "last=" + strconv.Itoa(window.Last) + "&unit=" + url.QueryEscape(window.Unit)
+}
+
+/*DataPoint is the total of a time series for a single period, i.e. the visits of a day*/
+type DataPoint struct {
+	Date  time.Time `json:"date"`
+	Total int       `json:"total"`
+}
+
+/*TimeSeries is a total broken down per period, sorted by date*/
+type TimeSeries struct {
+	DateFrom time.Time
+	DateTo   time.Time
+	Total    int
+	Points   []DataPoint
+}
+
+/*Sum returns the total of every point*/
+func (series TimeSeries) Sum() int {
+
+	sum := 0
+	for _, point := range series.Points {
+		sum += point.Total
+	}
+
+	return sum
+}
+
+/*Average returns the average total per point, or zero if there are none*/
+func (series TimeSeries) Average() float64 {
+
+	if len(series.Points) == 0 {
+		return 0
+	}
+
+	return float64(series.Sum()) / float64(len(series.Points))
+}
+
+/*Peak returns the point with the greatest total, the earliest one if several have it*/
+func (series TimeSeries) Peak() DataPoint {
+
+	var peak DataPoint
+	for i, point := range series.Points {
+		if i == 0 || point.Total > peak.Total {
+			peak = point
+		}
+	}
+
+	return peak
+}
+
+/*Between returns the points from one date to another, both included*/
+func (series TimeSeries) Between(from time.Time, to time.Time) TimeSeries {
+
+	between := TimeSeries{DateFrom: from, DateTo: to}
+
+	for _, point := range series.Points {
+		if !point.Date.Before(from) && !point.Date.After(to) {
+			between.Points = append(between.Points, point)
+			between.Total += point.Total
+		}
+	}
+
+	return between
+}
+
+/*ConversionRate returns the given sales as a fraction of the total, i.e. the visits, or zero if there were none*/
+func (series TimeSeries) ConversionRate(sales int) float64 {
+
+	if series.Total == 0 {
+		return 0
+	}
+
+	return float64(sales) / float64(series.Total)
+}
+
+/*AggregateSeries adds up several time series point by point, i.e. the visits of every item of a seller*/
+func AggregateSeries(series ...TimeSeries) TimeSeries {
+
+	var aggregated TimeSeries
+	indexes := make(map[int64]int)
+
+	for _, s := range series {
+
+		if aggregated.DateFrom.IsZero() || s.DateFrom.Before(aggregated.DateFrom) {
+			aggregated.DateFrom = s.DateFrom
+		}
+
+		if s.DateTo.After(aggregated.DateTo) {
+			aggregated.DateTo = s.DateTo
+		}
+
+		aggregated.Total += s.Total
+
+		for _, point := range s.Points {
+
+			key := point.Date.UnixNano()
+			if index, ok := indexes[key]; ok {
+				aggregated.Points[index].Total += point.Total
+				continue
+			}
+
+			indexes[key] = len(aggregated.Points)
+			aggregated.Points = append(aggregated.Points, point)
+		}
+	}
+
+	sortPoints(aggregated.Points)
+
+	return aggregated
+}
+
+func sortPoints(points []DataPoint) {
+	sort.SliceStable(points, func(i, j int) bool { return points[i].Date.Before(points[j].Date) })
+}
+
+/*visitsSeries is the JSON the time window APIs return*/
+type visitsSeries struct {
+	TotalVisits int         `json:"total_visits"`
+	DateFrom    time.Time   `json:"date_from"`
+	DateTo      time.Time   `json:"date_to"`
+	Results     []DataPoint `json:"results"`
+}
+
+func (series visitsSeries) timeSeries() *TimeSeries {
+
+	sortPoints(series.Results)
+
+	return &TimeSeries{DateFrom: series.DateFrom, DateTo: series.DateTo, Total: series.TotalVisits, Points: series.Results}
+}
+
+/*
+GetItemsVisits returns the total visits of every given item, by item id. Zero dates mean no limit.
+Items are requested 50 at a time.
+*/
+func (client *Client) GetItemsVisits(itemIDs []string, from time.Time, to time.Time) (map[string]int, error) {
+
+	visits := make(map[string]int, len(itemIDs))
+
+	for start := 0; start < len(itemIDs); start += maxVisitsItems {
+
+		end := start + maxVisitsItems
+		if end > len(itemIDs) {
+			end = len(itemIDs)
+		}
+
+		var result map[string]int
+		if err := client.getJSON("/visits/items?ids="+url.QueryEscape(strings.Join(itemIDs[start:end], ","))+dateRange(from, to), &result); err != nil {
+			return nil, err
+		}
+
+		for itemID, total := range result {
+			visits[itemID] = total
+		}
+	}
+
+	return visits, nil
+}
+
+/*GetItemVisitsSeries returns the visits of an item within the time window*/
+func (client *Client) GetItemVisitsSeries(itemID string, window TimeWindow) (*TimeSeries, error) {
+
+	var series visitsSeries
+	if err := client.getJSON("/items/"+url.PathEscape(itemID)+"/visits/time_window?"+window.query(), &series); err != nil {
+		return nil, err
+	}
+
+	return series.timeSeries(), nil
+}
+
+/*GetUserVisits returns the total visits of every item of the user the client acts on behalf of. Zero dates mean no limit.*/
+func (client *Client) GetUserVisits(from time.Time, to time.Time) (int, error) {
+
+	var result struct {
+		TotalVisits int `json:"total_visits"`
+	}
+
+	resource := "/users/" + strconv.FormatInt(client.UserID(), 10) + "/items_visits?" + strings.TrimPrefix(dateRange(from, to), "&")
+	if err := client.getJSON(resource, &result); err != nil {
+		return 0, err
+	}
+
+	return result.TotalVisits, nil
+}
+
+/*GetUserVisitsSeries returns the visits of every item of the user the client acts on behalf of within the time window*/
+func (client *Client) GetUserVisitsSeries(window TimeWindow) (*TimeSeries, error) {
+
+	var series visitsSeries
+	if err := client.getJSON("/users/"+strconv.FormatInt(client.UserID(), 10)+"/items_visits/time_window?"+window.query(), &series); err != nil {
+		return nil, err
+	}
+
+	return series.timeSeries(), nil
+}
+
+/*dateRange returns the date_from and date_to params, each preceded by &, for the dates which are not zero*/
+func dateRange(from time.Time, to time.Time) string {
+
+	params := ""
+
+	if !from.IsZero() {
+		params += "&date_from=" + from.Format("2006-01-02")
+	}
+
+	if !to.IsZero() {
+		params += "&date_to=" + to.Format("2006-01-02")
+	}
+
+	return params
+}
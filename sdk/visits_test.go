@@ -0,0 +1,132 @@
+/*
+Copyright [2016] [mercadolibre.com]
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/mercadolibre/golang-sdk/sdk"
+	"github.com/mercadolibre/golang-sdk/sdk/sdktest"
+)
+
+func Test_Items_visits_are_read_in_chunks_within_the_dates(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	today := time.Now().UTC()
+
+	ids := []string{}
+	for i := 1; i <= 60; i++ {
+		id := fmt.Sprintf("MLA%d", i)
+		ids = append(ids, id)
+		server.AddVisits(id, today, i)
+		server.AddVisits(id, today.AddDate(0, 0, -10), 100)
+	}
+
+	visits, err := client.GetItemsVisits(ids, today.AddDate(0, 0, -1), today)
+
+	if err != nil || len(visits) != 60 || visits["MLA1"] != 1 || visits["MLA60"] != 60 {
+		log.Printf("Error: unexpected visits %v %v", visits, err)
+		t.FailNow()
+	}
+
+	visits, err = client.GetItemsVisits([]string{"MLA1"}, time.Time{}, time.Time{})
+
+	if err != nil || visits["MLA1"] != 101 {
+		log.Printf("Error: unexpected visits without dates %v %v", visits, err)
+		t.FailNow()
+	}
+}
+
+func Test_Visits_time_series_are_broken_down_per_day_and_aggregated(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	server.AddItem(sdktest.Object{"id": "MLA1", "seller_id": testSellerID})
+	server.AddItem(sdktest.Object{"id": "MLA2", "seller_id": testSellerID})
+
+	today := time.Now().UTC()
+	server.AddVisits("MLA1", today, 10)
+	server.AddVisits("MLA1", today.AddDate(0, 0, -2), 30)
+	server.AddVisits("MLA2", today, 5)
+	server.AddVisits("MLA2", today.AddDate(0, 0, -9), 50)
+
+	first, err := client.GetItemVisitsSeries("MLA1", sdk.LastDays(7))
+
+	if err != nil || first.Total != 40 || len(first.Points) != 7 || first.Points[6].Total != 10 || first.Sum() != 40 {
+		log.Printf("Error: unexpected series %+v %v", first, err)
+		t.FailNow()
+	}
+
+	if peak := first.Peak(); peak.Total != 30 || !peak.Date.Equal(first.Points[4].Date) {
+		log.Printf("Error: unexpected peak %+v", peak)
+		t.FailNow()
+	}
+
+	if average := first.Average(); average < 5.71 || average > 5.72 {
+		log.Printf("Error: unexpected average %f", average)
+		t.FailNow()
+	}
+
+	if rate := first.ConversionRate(2); rate != 0.05 {
+		log.Printf("Error: unexpected conversion rate %f", rate)
+		t.FailNow()
+	}
+
+	second, _ := client.GetItemVisitsSeries("MLA2", sdk.LastDays(7))
+	aggregated := sdk.AggregateSeries(*first, *second)
+
+	if aggregated.Total != 45 || len(aggregated.Points) != 7 || aggregated.Points[6].Total != 15 {
+		log.Printf("Error: unexpected aggregated series %+v", aggregated)
+		t.FailNow()
+	}
+
+	user, err := client.GetUserVisitsSeries(sdk.LastDays(7))
+
+	if err != nil || user.Total != aggregated.Total || len(user.Points) != 7 || user.Points[6].Total != 15 {
+		log.Printf("Error: unexpected user series %+v %v", user, err)
+		t.FailNow()
+	}
+
+	if recent := user.Between(user.Points[5].Date, user.DateTo); recent.Total != 15 || len(recent.Points) != 2 {
+		log.Printf("Error: unexpected recent series %+v", recent)
+		t.FailNow()
+	}
+
+	total, err := client.GetUserVisits(time.Time{}, time.Time{})
+
+	if err != nil || total != 95 {
+		log.Printf("Error: unexpected user visits %d %v", total, err)
+		t.FailNow()
+	}
+}
+
+func Test_Invalid_time_window_fails(t *testing.T) {
+
+	server, client := newSellerClient(t)
+	defer server.Close()
+
+	if _, err := client.GetItemVisitsSeries("MLA1", sdk.TimeWindow{Last: 0, Unit: "day"}); err == nil {
+		log.Printf("Error: expected an error for an empty time window")
+		t.FailNow()
+	}
+}